@@ -0,0 +1,54 @@
+package goproject
+
+import "time"
+
+// DelayQueue holds tasks until the time they are scheduled for. Tasks come
+// out earliest first; tasks due at the same instant come out in the order
+// they were scheduled. It is not safe for concurrent use.
+type DelayQueue struct {
+	h   Heap[delayedTask]
+	now func() time.Time
+}
+
+type delayedTask struct {
+	at   time.Time
+	task func()
+}
+
+// NewDelayQueue returns an empty DelayQueue driven by the wall clock.
+func NewDelayQueue() *DelayQueue {
+	return &DelayQueue{
+		h:   NewHeapFunc(func(a, b delayedTask) bool { return a.at.Before(b.at) }, WithStable()),
+		now: time.Now,
+	}
+}
+
+// Schedule queues task to become due at at.
+func (q *DelayQueue) Schedule(at time.Time, task func()) {
+	q.h.Push(delayedTask{at: at, task: task})
+}
+
+// Next removes and returns the earliest task if it is due. ok is false if
+// the queue is empty or nothing is due yet.
+func (q *DelayQueue) Next() (task func(), ok bool) {
+	top, ok := q.h.Peek()
+	if !ok || top.at.After(q.now()) {
+		return nil, false
+	}
+	q.h.Pop()
+	return top.task, true
+}
+
+// UntilNext returns how long until the earliest task is due, or 0 if it
+// already is. ok is false if the queue is empty.
+func (q *DelayQueue) UntilNext() (d time.Duration, ok bool) {
+	top, ok := q.h.Peek()
+	if !ok {
+		return 0, false
+	}
+	return max(top.at.Sub(q.now()), 0), true
+}
+
+func (q *DelayQueue) Len() int {
+	return q.h.Len()
+}
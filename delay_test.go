@@ -0,0 +1,64 @@
+package goproject
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDelayQueue(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	now := start
+	q := NewDelayQueue()
+	q.now = func() time.Time { return now }
+
+	if _, ok := q.Next(); ok {
+		t.Fatalf("Next on an empty queue reported a task")
+	}
+	if _, ok := q.UntilNext(); ok {
+		t.Fatalf("UntilNext on an empty queue reported ok")
+	}
+
+	var ran []string
+	schedule := func(after time.Duration, name string) {
+		q.Schedule(start.Add(after), func() { ran = append(ran, name) })
+	}
+	schedule(30*time.Second, "report")
+	schedule(5*time.Second, "poll inverter")
+	schedule(20*time.Second, "rotate tracker")
+	schedule(5*time.Second, "poll meter")
+	schedule(-time.Second, "overdue")
+
+	if d, ok := q.UntilNext(); !ok || d != 0 {
+		t.Fatalf("UntilNext with an overdue task = (%v, %v), want 0", d, ok)
+	}
+	runDue := func() {
+		for {
+			task, ok := q.Next()
+			if !ok {
+				return
+			}
+			task()
+		}
+	}
+	runDue()
+	if d, ok := q.UntilNext(); !ok || d != 5*time.Second {
+		t.Fatalf("UntilNext = (%v, %v), want 5s", d, ok)
+	}
+	now = start.Add(20 * time.Second)
+	runDue()
+	now = start.Add(time.Minute)
+	runDue()
+
+	want := []string{"overdue", "poll inverter", "poll meter", "rotate tracker", "report"}
+	if len(ran) != len(want) {
+		t.Fatalf("ran %v, want %v", ran, want)
+	}
+	for i := range want {
+		if ran[i] != want[i] {
+			t.Fatalf("ran %v, want %v", ran, want)
+		}
+	}
+	if q.Len() != 0 {
+		t.Fatalf("queue not drained: %d left", q.Len())
+	}
+}
@@ -0,0 +1,76 @@
+package goproject
+
+// Deque is a double-ended queue backed by a ring buffer, so pushes and pops
+// at either end are amortised O(1) and popping from the front does not
+// leak the slice's head the way reslicing would. The zero Deque is empty
+// and ready to use.
+type Deque[T any] struct {
+	buf  []T
+	head int // index of the front element in buf
+	n    int
+}
+
+func (d *Deque[T]) Len() int {
+	return d.n
+}
+
+// PushBack appends x at the back.
+func (d *Deque[T]) PushBack(x T) {
+	if d.n == len(d.buf) {
+		d.grow()
+	}
+	d.buf[(d.head+d.n)%len(d.buf)] = x
+	d.n++
+}
+
+// PopBack removes and returns the back element. ok is false if the deque
+// is empty.
+func (d *Deque[T]) PopBack() (x T, ok bool) {
+	if d.n == 0 {
+		return x, false
+	}
+	i := (d.head + d.n - 1) % len(d.buf)
+	x = d.buf[i]
+	var zero T
+	d.buf[i] = zero
+	d.n--
+	return x, true
+}
+
+// PopFront removes and returns the front element; see PopBack.
+func (d *Deque[T]) PopFront() (x T, ok bool) {
+	if d.n == 0 {
+		return x, false
+	}
+	x = d.buf[d.head]
+	var zero T
+	d.buf[d.head] = zero
+	d.head = (d.head + 1) % len(d.buf)
+	d.n--
+	return x, true
+}
+
+// Front returns the front element without removing it.
+func (d *Deque[T]) Front() (x T, ok bool) {
+	if d.n == 0 {
+		return x, false
+	}
+	return d.buf[d.head], true
+}
+
+// Back returns the back element without removing it.
+func (d *Deque[T]) Back() (x T, ok bool) {
+	if d.n == 0 {
+		return x, false
+	}
+	return d.buf[(d.head+d.n-1)%len(d.buf)], true
+}
+
+// grow doubles the buffer, unwrapping the elements to start at index 0.
+func (d *Deque[T]) grow() {
+	buf := make([]T, max(2*len(d.buf), 4))
+	for i := 0; i < d.n; i++ {
+		buf[i] = d.buf[(d.head+i)%len(d.buf)]
+	}
+	d.buf, d.head = buf, 0
+}
@@ -0,0 +1,54 @@
+package goproject
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestDeque(t *testing.T) {
+	var d Deque[int]
+	if _, ok := d.PopFront(); ok {
+		t.Fatalf("PopFront on an empty deque reported ok")
+	}
+	if _, ok := d.Back(); ok {
+		t.Fatalf("Back on an empty deque reported ok")
+	}
+
+	// Compare against a plain slice through enough wrap-arounds and growth.
+	r := rand.New(rand.NewSource(44))
+	var ref []int
+	for op := 0; op < 5000; op++ {
+		switch r.Intn(4) {
+		case 0, 1:
+			x := r.Intn(1000)
+			d.PushBack(x)
+			ref = append(ref, x)
+		case 2:
+			x, ok := d.PopFront()
+			if ok != (len(ref) > 0) || ok && x != ref[0] {
+				t.Fatalf("PopFront = (%d, %v), want front of %v", x, ok, ref)
+			}
+			if ok {
+				ref = ref[1:]
+			}
+		case 3:
+			x, ok := d.PopBack()
+			if ok != (len(ref) > 0) || ok && x != ref[len(ref)-1] {
+				t.Fatalf("PopBack = (%d, %v), want back of %v", x, ok, ref)
+			}
+			if ok {
+				ref = ref[:len(ref)-1]
+			}
+		}
+		if d.Len() != len(ref) {
+			t.Fatalf("Len = %d, want %d", d.Len(), len(ref))
+		}
+		if len(ref) > 0 {
+			f, _ := d.Front()
+			b, _ := d.Back()
+			if f != ref[0] || b != ref[len(ref)-1] {
+				t.Fatalf("Front/Back = %d/%d, want %d/%d", f, b, ref[0], ref[len(ref)-1])
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package goproject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+)
+
+// MarshalJSON encodes the heap as a JSON array of its elements in backing
+// (heap) order, which is cheaper to produce than a sorted form. The
+// ordering itself is not encoded.
+func (h Heap[T]) MarshalJSON() ([]byte, error) {
+	if h.c == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(h.c)
+}
+
+// UnmarshalJSON replaces the heap's elements with those in the JSON array
+// and rebuilds the heap with the receiver's ordering, so input that is not
+// in heap order (hand-edited or produced elsewhere) still yields a valid
+// heap.
+func (h *Heap[T]) UnmarshalJSON(data []byte) error {
+	var xs []T
+	if err := json.Unmarshal(data, &xs); err != nil {
+		return err
+	}
+	h.load(xs)
+	h.trace("unmarshaljson")
+	return nil
+}
+
+// GobEncode encodes the heap's elements in backing order for encoding/gob,
+// which is smaller and faster than JSON for large heaps. As with
+// MarshalJSON, the ordering itself is not encoded.
+func (h Heap[T]) GobEncode() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(h.c); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GobDecode replaces the heap's elements with those decoded from data and
+// rebuilds the heap with the receiver's ordering, like UnmarshalJSON.
+func (h *Heap[T]) GobDecode(data []byte) error {
+	var xs []T
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&xs); err != nil {
+		return err
+	}
+	h.load(xs)
+	h.trace("gobdecode")
+	return nil
+}
+
+// load makes xs the heap's elements and restores the heap property.
+func (h *Heap[T]) load(xs []T) {
+	h.truncate(0)
+	h.c = xs
+	if h.moved != nil {
+		for i, x := range xs {
+			h.moved(x, i)
+		}
+	}
+	if h.stable {
+		// Insertion order is not encoded; equal elements are renumbered in
+		// the order they appear in the array.
+		h.seq = make([]uint64, len(xs))
+		for i := range h.seq {
+			h.seq[i] = h.next
+			h.next++
+		}
+	}
+	h.heapify()
+}
@@ -0,0 +1,123 @@
+package goproject
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"math/rand"
+	"testing"
+)
+
+func TestHeapJSONRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(16))
+	large := make([]int, 5000)
+	for i := range large {
+		large[i] = r.Intn(1000) - 500
+	}
+	for _, xs := range [][]int{nil, {42}, large} {
+		h := Heapify(xs)
+		data, err := json.Marshal(h)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		var got IntHeap
+		if err := json.Unmarshal(data, &got); err != nil {
+			t.Fatalf("Unmarshal(%.40s): %v", data, err)
+		}
+		assertHeap(t, &got)
+		if !equalInts(got.Drain(), h.Drain()) {
+			t.Fatalf("round trip of %d elements changed the contents", len(xs))
+		}
+	}
+}
+
+func TestHeapJSONReheapifies(t *testing.T) {
+	h := NewMinHeap()
+	if err := json.Unmarshal([]byte("[9, 1, 7, -3, 1]"), &h); err != nil {
+		t.Fatal(err)
+	}
+	assertHeap(t, &h)
+	if got := h.Drain(); !equalInts(got, []int{-3, 1, 1, 7, 9}) {
+		t.Fatalf("min heap from unordered JSON drains %v", got)
+	}
+	if data, _ := json.Marshal(h); string(data) != "[]" {
+		t.Fatalf("empty heap marshals to %s, want []", data)
+	}
+	if err := json.Unmarshal([]byte(`{"c": 1}`), &h); err == nil {
+		t.Fatalf("Unmarshal accepted a non-array")
+	}
+}
+
+func TestHeapJSONInStruct(t *testing.T) {
+	type checkpoint struct {
+		Offset int            `json:"offset"`
+		Window IntHeap        `json:"window"`
+		Names  Heap[string]   `json:"names"`
+		Ptr    *Heap[float64] `json:"ptr"`
+	}
+	floats := NewMinHeapOf[float64]()
+	floats.Push(2.5, -0.5, 1)
+	in := checkpoint{Offset: 7, Window: Heapify([]int{3, 8, 1}), Ptr: &floats}
+	in.Names.Push("b", "a", "c")
+
+	data, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minFloats := NewMinHeapOf[float64]()
+	out := checkpoint{Ptr: &minFloats}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Offset != 7 || !equalInts(out.Window.Drain(), []int{8, 3, 1}) {
+		t.Fatalf("decoded checkpoint %s", data)
+	}
+	if got := out.Names.Drain(); len(got) != 3 || got[0] != "c" || got[2] != "a" {
+		t.Fatalf("decoded names pop %q", got)
+	}
+	if got := out.Ptr.Drain(); len(got) != 3 || got[0] != -0.5 || got[2] != 2.5 {
+		t.Fatalf("decoded min heap pops %v; the receiver's ordering was not kept", got)
+	}
+}
+
+func TestHeapGobRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(49))
+	large := make([]int, 100000)
+	for i := range large {
+		large[i] = r.Intn(1000) - 500
+	}
+	for _, xs := range [][]int{nil, {42}, large} {
+		h := Heapify(xs)
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(h); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+		var got IntHeap
+		if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+			t.Fatalf("Decode: %v", err)
+		}
+		assertHeap(t, &got)
+		if !equalInts(got.Drain(), h.Drain()) {
+			t.Fatalf("round trip of %d elements changed the pop sequence", len(xs))
+		}
+	}
+}
+
+func TestHeapGobReheapifies(t *testing.T) {
+	src := Heapify([]int{9, 1, 7, -3, 1})
+	data, err := src.GobEncode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := NewMinHeap()
+	if err := h.GobDecode(data); err != nil {
+		t.Fatal(err)
+	}
+	assertHeap(t, &h)
+	if got := h.Drain(); !equalInts(got, []int{-3, 1, 1, 7, 9}) {
+		t.Fatalf("min heap decoded from a max heap drains %v", got)
+	}
+	if err := h.GobDecode([]byte("not gob")); err == nil {
+		t.Fatalf("GobDecode accepted garbage")
+	}
+}
@@ -0,0 +1,46 @@
+package goproject
+
+import "errors"
+
+// ErrNegativeWeight is returned by Dijkstra for a graph with a negative
+// edge weight, for which it cannot guarantee shortest distances.
+var ErrNegativeWeight = errors.New("goproject: negative edge weight")
+
+// Edge is a directed, weighted edge of an adjacency list.
+type Edge struct {
+	To     int
+	Weight int
+}
+
+// Dijkstra returns the shortest distance from src to every node reachable
+// from it in the directed graph adj, including src itself at distance 0.
+// Unreachable nodes are absent from the result. Any negative weight in adj
+// makes it return ErrNegativeWeight. The frontier is an IndexedPQ, so each
+// shorter path found costs one DecreaseKey.
+func Dijkstra(adj map[int][]Edge, src int) (map[int]int, error) {
+	for _, edges := range adj {
+		for _, e := range edges {
+			if e.Weight < 0 {
+				return nil, ErrNegativeWeight
+			}
+		}
+	}
+	dist := map[int]int{}
+	q := NewIndexedPQ()
+	q.Insert(src, 0)
+	for q.Len() > 0 {
+		u, d, _ := q.Pop()
+		dist[u] = d
+		for _, e := range adj[u] {
+			if _, done := dist[e.To]; done {
+				continue
+			}
+			if !q.Contains(e.To) {
+				q.Insert(e.To, d+e.Weight)
+			} else {
+				q.DecreaseKey(e.To, d+e.Weight)
+			}
+		}
+	}
+	return dist, nil
+}
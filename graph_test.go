@@ -0,0 +1,48 @@
+package goproject
+
+import (
+	"errors"
+	"maps"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	adj := map[int][]Edge{
+		0: {{1, 4}, {2, 1}},
+		2: {{1, 2}, {3, 5}},
+		1: {{3, 1}},
+		3: {{4, 3}},
+		4: {{0, 1}},
+	}
+	got, err := Dijkstra(adj, 0)
+	if want := map[int]int{0: 0, 1: 3, 2: 1, 3: 4, 4: 7}; err != nil || !maps.Equal(got, want) {
+		t.Fatalf("Dijkstra = (%v, %v), want %v", got, err, want)
+	}
+
+	// 5 and 6 only reach each other; 7 has no edges at all.
+	disconnected := map[int][]Edge{
+		0: {{1, 2}},
+		1: {{0, 2}},
+		5: {{6, 1}},
+		6: {{5, 1}},
+	}
+	got, err = Dijkstra(disconnected, 0)
+	if want := map[int]int{0: 0, 1: 2}; err != nil || !maps.Equal(got, want) {
+		t.Fatalf("Dijkstra on a disconnected graph = (%v, %v), want %v", got, err, want)
+	}
+	got, err = Dijkstra(disconnected, 7)
+	if want := map[int]int{7: 0}; err != nil || !maps.Equal(got, want) {
+		t.Fatalf("Dijkstra from an isolated node = (%v, %v), want %v", got, err, want)
+	}
+
+	zero := map[int][]Edge{0: {{1, 0}}, 1: {{2, 0}}}
+	got, err = Dijkstra(zero, 0)
+	if want := map[int]int{0: 0, 1: 0, 2: 0}; err != nil || !maps.Equal(got, want) {
+		t.Fatalf("Dijkstra with zero weights = (%v, %v), want %v", got, err, want)
+	}
+
+	negative := map[int][]Edge{0: {{1, 3}}, 9: {{0, -1}}}
+	if got, err := Dijkstra(negative, 0); !errors.Is(err, ErrNegativeWeight) || got != nil {
+		t.Fatalf("Dijkstra with a negative weight = (%v, %v), want ErrNegativeWeight", got, err)
+	}
+}
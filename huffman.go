@@ -0,0 +1,51 @@
+package goproject
+
+// huffmanNode is a leaf for one symbol or an internal node joining two
+// subtrees. low is the smallest symbol below the node and only breaks ties
+// between equal frequencies, so the codes do not depend on map order.
+type huffmanNode struct {
+	freq        int
+	sym, low    byte
+	left, right *huffmanNode
+}
+
+// HuffmanCodes returns an optimal prefix-free binary code for the symbols
+// in freqs. It repeatedly merges the two least frequent subtrees taken from
+// a min-heap; the path to each leaf, 0 for left and 1 for right, is its
+// code. A single symbol gets the one-bit code "0", and an empty freqs gives
+// an empty map.
+func HuffmanCodes(freqs map[byte]int) map[byte]string {
+	codes := make(map[byte]string, len(freqs))
+	h := NewHeapFunc(func(a, b *huffmanNode) bool {
+		if a.freq != b.freq {
+			return a.freq < b.freq
+		}
+		return a.low < b.low
+	}, WithCapacity(len(freqs)))
+	for sym, f := range freqs {
+		h.Push(&huffmanNode{freq: f, sym: sym, low: sym})
+	}
+	if h.Len() == 1 {
+		root, _ := h.Pop()
+		codes[root.sym] = "0"
+		return codes
+	}
+	for h.Len() > 1 {
+		a, _ := h.Pop()
+		b, _ := h.Pop()
+		h.Push(&huffmanNode{freq: a.freq + b.freq, low: min(a.low, b.low), left: a, right: b})
+	}
+	var walk func(n *huffmanNode, code string)
+	walk = func(n *huffmanNode, code string) {
+		if n.left == nil {
+			codes[n.sym] = code
+			return
+		}
+		walk(n.left, code+"0")
+		walk(n.right, code+"1")
+	}
+	if root, ok := h.Pop(); ok {
+		walk(root, "")
+	}
+	return codes
+}
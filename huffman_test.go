@@ -0,0 +1,70 @@
+package goproject
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestHuffmanCodes(t *testing.T) {
+	freqs := map[byte]int{'a': 45, 'b': 13, 'c': 12, 'd': 16, 'e': 9, 'f': 5}
+	codes := HuffmanCodes(freqs)
+	wantLen := map[byte]int{'a': 1, 'b': 3, 'c': 3, 'd': 3, 'e': 4, 'f': 4}
+	for sym, n := range wantLen {
+		if len(codes[sym]) != n {
+			t.Errorf("code for %q = %q, want %d bits", sym, codes[sym], n)
+		}
+	}
+	checkHuffman(t, freqs, codes)
+
+	if got := HuffmanCodes(map[byte]int{'x': 7}); len(got) != 1 || got['x'] != "0" {
+		t.Fatalf("single symbol codes = %v, want x:0", got)
+	}
+	if got := HuffmanCodes(nil); got == nil || len(got) != 0 {
+		t.Fatalf("HuffmanCodes(nil) = %#v, want empty", got)
+	}
+
+	r := rand.New(rand.NewSource(38))
+	for iter := 0; iter < 50; iter++ {
+		freqs := map[byte]int{}
+		for i := 0; i < 2+r.Intn(40); i++ {
+			freqs[byte(r.Intn(256))] = 1 + r.Intn(100)
+		}
+		codes := HuffmanCodes(freqs)
+		checkHuffman(t, freqs, codes)
+		if again := HuffmanCodes(freqs); len(again) != len(codes) {
+			t.Fatalf("HuffmanCodes is not deterministic")
+		} else {
+			for sym, c := range codes {
+				if again[sym] != c {
+					t.Fatalf("HuffmanCodes is not deterministic: %q got %q then %q", sym, c, again[sym])
+				}
+			}
+		}
+	}
+}
+
+// checkHuffman verifies that codes covers freqs, is prefix-free, and never
+// gives a more frequent symbol a longer code.
+func checkHuffman(t *testing.T, freqs map[byte]int, codes map[byte]string) {
+	t.Helper()
+	if len(codes) != len(freqs) {
+		t.Fatalf("%d codes for %d symbols", len(codes), len(freqs))
+	}
+	for a, ca := range codes {
+		if ca == "" || strings.Trim(ca, "01") != "" {
+			t.Fatalf("code for %q = %q is not a bit string", a, ca)
+		}
+		for b, cb := range codes {
+			if a == b {
+				continue
+			}
+			if strings.HasPrefix(cb, ca) {
+				t.Fatalf("code %q for %q is a prefix of %q for %q", ca, a, cb, b)
+			}
+			if freqs[a] > freqs[b] && len(ca) > len(cb) {
+				t.Fatalf("%q (freq %d) has code %q, longer than %q (freq %d) with %q", a, freqs[a], ca, b, freqs[b], cb)
+			}
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package goproject
+
+// IndexedHeap is a max-heap of keys ordered by an int priority, or a
+// min-heap if built by NewMinIndexedHeap. It is a Heap of entries that
+// tracks where each key sits in the backing slice, so the priority of a
+// queued key can be changed, or the key removed, in O(log n). The zero
+// IndexedHeap is an empty binary max-heap.
+type IndexedHeap[K comparable] struct {
+	h   Heap[indexedEntry[K]]
+	pos map[K]int
+}
+
+type indexedEntry[K comparable] struct {
+	key      K
+	priority int
+}
+
+// NewIndexedHeap returns an empty indexed heap configured by opts, such as
+// WithArity, WithStats or WithMinOrdering.
+func NewIndexedHeap[K comparable](opts ...Option) IndexedHeap[K] {
+	var h IndexedHeap[K]
+	h.init(opts)
+	return h
+}
+
+// NewMinIndexedHeap returns an empty indexed heap that pops the lowest
+// priority first.
+func NewMinIndexedHeap[K comparable](opts ...Option) IndexedHeap[K] {
+	return NewIndexedHeap[K](append(opts, WithMinOrdering())...)
+}
+
+// init builds the underlying heap and hooks its moves up to pos. The hook
+// captures the map rather than h, so copies of the IndexedHeap share it.
+func (h *IndexedHeap[K]) init(opts []Option) {
+	before := func(a, b indexedEntry[K]) bool { return a.priority > b.priority }
+	if collectOptions(opts).min {
+		before = func(a, b indexedEntry[K]) bool { return a.priority < b.priority }
+	}
+	pos := make(map[K]int)
+	h.h = newHeap(0, before, opts...)
+	h.h.moved = func(e indexedEntry[K], i int) {
+		if i < 0 {
+			delete(pos, e.key)
+		} else {
+			pos[e.key] = i
+		}
+	}
+	h.pos = pos
+}
+
+func (h *IndexedHeap[K]) Len() int {
+	return h.h.Len()
+}
+
+// Contains reports whether key is queued, in O(1).
+func (h *IndexedHeap[K]) Contains(key K) bool {
+	_, ok := h.pos[key]
+	return ok
+}
+
+// Priority returns the priority key is queued with.
+func (h *IndexedHeap[K]) Priority(key K) (int, bool) {
+	i, ok := h.pos[key]
+	if !ok {
+		return 0, false
+	}
+	return h.h.c[i].priority, true
+}
+
+// Push queues key with priority, or updates its priority if it is already
+// queued.
+func (h *IndexedHeap[K]) Push(key K, priority int) {
+	if h.Update(key, priority) {
+		return
+	}
+	if h.pos == nil {
+		h.init(nil)
+	}
+	h.h.Push(indexedEntry[K]{key: key, priority: priority})
+}
+
+// Update changes the priority of a queued key and moves it to its new place.
+// It reports false if key is not queued.
+func (h *IndexedHeap[K]) Update(key K, priority int) bool {
+	i, ok := h.pos[key]
+	if !ok {
+		return false
+	}
+	h.h.c[i].priority = priority
+	h.h.fix(i)
+	return true
+}
+
+// Peek returns the top key, the one with the highest priority (lowest for
+// a min heap), without removing it.
+func (h *IndexedHeap[K]) Peek() (key K, priority int, ok bool) {
+	e, ok := h.h.Peek()
+	return e.key, e.priority, ok
+}
+
+// Pop removes and returns the top key; see Peek.
+func (h *IndexedHeap[K]) Pop() (key K, priority int, ok bool) {
+	e, ok := h.h.Pop()
+	return e.key, e.priority, ok
+}
+
+// Remove drops key from the heap, returning its priority. It reports false
+// if key is not queued.
+func (h *IndexedHeap[K]) Remove(key K) (int, bool) {
+	i, ok := h.pos[key]
+	if !ok {
+		return 0, false
+	}
+	return h.h.removeAt(i).priority, true
+}
+
+// Stats returns the underlying heap's counters; see Heap.Stats.
+func (h *IndexedHeap[K]) Stats() HeapStats {
+	return h.h.Stats()
+}
+
+// IndexedPQ is a min-priority queue of int ids with DecreaseKey, the
+// frontier Dijkstra's algorithm needs.
+type IndexedPQ struct {
+	h IndexedHeap[int]
+}
+
+// NewIndexedPQ returns an empty IndexedPQ.
+func NewIndexedPQ() *IndexedPQ {
+	return &IndexedPQ{h: NewMinIndexedHeap[int]()}
+}
+
+func (q *IndexedPQ) Len() int {
+	return q.h.Len()
+}
+
+// Contains reports whether id is queued.
+func (q *IndexedPQ) Contains(id int) bool {
+	return q.h.Contains(id)
+}
+
+// Insert queues id with priority, replacing its priority if it is already
+// queued.
+func (q *IndexedPQ) Insert(id, priority int) {
+	q.h.Push(id, priority)
+}
+
+// DecreaseKey lowers the priority of a queued id in O(log n). It reports
+// false, changing nothing, if id is not queued or newPriority is not lower
+// than its current priority.
+func (q *IndexedPQ) DecreaseKey(id, newPriority int) bool {
+	if p, ok := q.h.Priority(id); !ok || newPriority >= p {
+		return false
+	}
+	return q.h.Update(id, newPriority)
+}
+
+// Pop removes and returns the id with the lowest priority. ok is false if
+// the queue is empty.
+func (q *IndexedPQ) Pop() (id, priority int, ok bool) {
+	return q.h.Pop()
+}
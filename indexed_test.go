@@ -0,0 +1,178 @@
+package goproject
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestIndexedHeap(t *testing.T) {
+	h := NewIndexedHeap[string]()
+	h.Push("east", 4)
+	h.Push("west", 9)
+	h.Push("north", 1)
+	h.Push("south", 6)
+	if !h.Update("north", 10) || h.Update("up", 3) {
+		t.Fatalf("Update reported the wrong keys as present")
+	}
+	h.Push("west", 2) // already queued: behaves as Update
+	if p, ok := h.Remove("south"); !ok || p != 6 {
+		t.Fatalf("Remove(south) = (%d, %v)", p, ok)
+	}
+	if h.Contains("south") || !h.Contains("east") || h.Len() != 3 {
+		t.Fatalf("Contains/Len wrong after Remove: len %d", h.Len())
+	}
+	for _, want := range []struct {
+		key string
+		p   int
+	}{{"north", 10}, {"east", 4}, {"west", 2}} {
+		if k, p, ok := h.Pop(); !ok || k != want.key || p != want.p {
+			t.Fatalf("Pop = (%s, %d, %v), want (%s, %d)", k, p, ok, want.key, want.p)
+		}
+	}
+	if _, _, ok := h.Pop(); ok {
+		t.Fatalf("Pop on empty heap reported a key")
+	}
+}
+
+func TestIndexedHeapStress(t *testing.T) {
+	r := rand.New(rand.NewSource(21))
+	var h IndexedHeap[int]
+	ref := make(map[int]int)
+	for op := 0; op < 5000; op++ {
+		key := r.Intn(60)
+		switch r.Intn(4) {
+		case 0, 1:
+			p := r.Intn(100)
+			h.Push(key, p)
+			ref[key] = p
+		case 2:
+			_, want := ref[key]
+			if _, ok := h.Remove(key); ok != want {
+				t.Fatalf("Remove(%d) = %v, want %v", key, ok, want)
+			}
+			delete(ref, key)
+		case 3:
+			k, p, ok := h.Pop()
+			if ok != (len(ref) > 0) {
+				t.Fatalf("Pop ok = %v with %d keys queued", ok, len(ref))
+			}
+			if !ok {
+				continue
+			}
+			for rk, rp := range ref {
+				if rp > p {
+					t.Fatalf("Pop returned %d@%d but %d@%d is higher", k, p, rk, rp)
+				}
+			}
+			if ref[k] != p {
+				t.Fatalf("Pop returned %d@%d, queued at %d", k, p, ref[k])
+			}
+			delete(ref, k)
+		}
+		if h.Len() != len(ref) || len(h.pos) != len(ref) {
+			t.Fatalf("Len %d, %d positions, want %d", h.Len(), len(h.pos), len(ref))
+		}
+		for i, e := range h.h.c {
+			if h.pos[e.key] != i {
+				t.Fatalf("pos[%d] = %d, entry is at %d", e.key, h.pos[e.key], i)
+			}
+		}
+		if err := h.h.CheckInvariant(); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestIndexedHeapOptions(t *testing.T) {
+	r := rand.New(rand.NewSource(22))
+	for _, d := range []int{2, 3, 4, 8} {
+		h := NewMinIndexedHeap[int](WithArity(d), WithStats())
+		ref := make(map[int]int)
+		for op := 0; op < 3000; op++ {
+			key, p := r.Intn(80), r.Intn(1000)
+			switch r.Intn(3) {
+			case 0, 1:
+				h.Push(key, p)
+				ref[key] = p
+			case 2:
+				if _, ok := h.Remove(key); ok {
+					delete(ref, key)
+				}
+			}
+			if err := h.h.CheckInvariant(); err != nil {
+				t.Fatalf("arity %d: %v", d, err)
+			}
+		}
+		last := -1
+		for h.Len() > 0 {
+			k, p, _ := h.Pop()
+			if p < last || ref[k] != p {
+				t.Fatalf("arity %d: Pop returned %d@%d after priority %d, queued at %d", d, k, p, last, ref[k])
+			}
+			delete(ref, k)
+			last = p
+		}
+		if len(ref) != 0 || len(h.pos) != 0 {
+			t.Fatalf("arity %d: %d keys left in ref, %d positions", d, len(ref), len(h.pos))
+		}
+		if s := h.Stats(); s.Pushes == 0 || s.Comparisons == 0 || s.Swaps == 0 {
+			t.Fatalf("arity %d: WithStats counted %+v", d, s)
+		}
+	}
+}
+
+func TestIndexedPQ(t *testing.T) {
+	q := NewIndexedPQ()
+	for id, p := range []int{50, 40, 30, 20, 10} {
+		q.Insert(id, p)
+	}
+	if !q.DecreaseKey(0, 5) || !q.DecreaseKey(2, 15) || !q.DecreaseKey(1, 15) {
+		t.Fatalf("DecreaseKey on queued ids reported false")
+	}
+	if q.DecreaseKey(3, 25) || q.DecreaseKey(9, 1) {
+		t.Fatalf("DecreaseKey accepted a raise or an unknown id")
+	}
+	if !q.Contains(4) || q.Contains(9) || q.Len() != 5 {
+		t.Fatalf("Contains/Len wrong: len %d", q.Len())
+	}
+	var got []int
+	last := -1
+	for q.Len() > 0 {
+		id, p, ok := q.Pop()
+		if !ok || p < last {
+			t.Fatalf("Pop = (%d, %d, %v) after priority %d", id, p, ok, last)
+		}
+		got = append(got, id)
+		last = p
+	}
+	if got[0] != 0 || got[1] != 4 || got[4] != 3 {
+		t.Fatalf("pop order %v, want 0 then 4 first and 3 last", got)
+	}
+	if _, _, ok := q.Pop(); ok {
+		t.Fatalf("Pop on empty queue reported an id")
+	}
+
+	r := rand.New(rand.NewSource(34))
+	ref := map[int]int{}
+	for id := 0; id < 200; id++ {
+		p := r.Intn(1000)
+		q.Insert(id, p)
+		ref[id] = p
+	}
+	for i := 0; i < 300; i++ {
+		id := r.Intn(200)
+		p := ref[id] - r.Intn(50)
+		if q.DecreaseKey(id, p) != (p < ref[id]) {
+			t.Fatalf("DecreaseKey(%d, %d) from %d misreported", id, p, ref[id])
+		}
+		ref[id] = min(ref[id], p)
+	}
+	last = -1 << 31
+	for q.Len() > 0 {
+		id, p, _ := q.Pop()
+		if p != ref[id] || p < last {
+			t.Fatalf("Pop = (%d, %d), want priority %d and at least %d", id, p, ref[id], last)
+		}
+		last = p
+	}
+}
@@ -0,0 +1,56 @@
+package goproject
+
+// byStart returns a min-heap of intervals ordered by start, then end.
+func byStart(intervals [][]int) Heap[[]int] {
+	h := NewHeapFunc(func(a, b []int) bool {
+		if a[0] != b[0] {
+			return a[0] < b[0]
+		}
+		return a[1] < b[1]
+	}, WithCapacity(len(intervals)))
+	h.Push(intervals...)
+	return h
+}
+
+// MinMeetingRooms returns the fewest rooms that can hold every meeting in
+// intervals, each given as [start, end]: the largest number of meetings
+// running at once. Meetings are taken in order of start from one min-heap
+// while another holds the end times of those in progress. The intervals are
+// half-open, so a meeting ending at 2 frees its room for one starting at 2.
+func MinMeetingRooms(intervals [][]int) int {
+	meetings := byStart(intervals)
+	ends := NewMinHeap(WithCapacity(len(intervals)))
+	rooms := 0
+	for {
+		m, ok := meetings.Pop()
+		if !ok {
+			return rooms
+		}
+		if end, ok := ends.Peek(); ok && end <= m[0] {
+			ends.Replace(m[1])
+		} else {
+			ends.Push(m[1])
+		}
+		rooms = max(rooms, ends.Len())
+	}
+}
+
+// MergeIntervals returns the union of intervals, each given as
+// [start, end], as disjoint intervals in increasing order. The intervals
+// are closed, so [1,2] and [2,3] touch and merge into [1,3]. The input is
+// not modified.
+func MergeIntervals(intervals [][]int) [][]int {
+	h := byStart(intervals)
+	res := [][]int{}
+	for {
+		iv, ok := h.Pop()
+		if !ok {
+			return res
+		}
+		if n := len(res); n > 0 && iv[0] <= res[n-1][1] {
+			res[n-1][1] = max(res[n-1][1], iv[1])
+		} else {
+			res = append(res, []int{iv[0], iv[1]})
+		}
+	}
+}
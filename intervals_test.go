@@ -0,0 +1,101 @@
+package goproject
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestMinMeetingRooms(t *testing.T) {
+	tests := []struct {
+		intervals [][]int
+		want      int
+	}{
+		{[][]int{{0, 30}, {5, 10}, {15, 20}}, 2},
+		{[][]int{{1, 9}, {2, 8}, {3, 7}, {4, 6}}, 4},
+		{[][]int{{1, 2}, {3, 4}, {5, 6}}, 1},
+		{[][]int{{1, 2}, {2, 3}, {3, 4}}, 1},
+		{[][]int{{2, 3}, {1, 2}, {1, 2}}, 2},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := MinMeetingRooms(tt.intervals); got != tt.want {
+			t.Errorf("MinMeetingRooms(%v) = %d, want %d", tt.intervals, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(48))
+	for iter := 0; iter < 200; iter++ {
+		intervals := randomIntervals(r)
+		want := 0
+		for x := 0; x < 30; x++ {
+			n := 0
+			for _, iv := range intervals {
+				if iv[0] <= x && x < iv[1] {
+					n++
+				}
+			}
+			want = max(want, n)
+		}
+		if got := MinMeetingRooms(intervals); got != want {
+			t.Fatalf("MinMeetingRooms(%v) = %d, want %d", intervals, got, want)
+		}
+	}
+}
+
+func TestMergeIntervals(t *testing.T) {
+	tests := []struct {
+		intervals [][]int
+		want      [][]int
+	}{
+		{[][]int{{8, 10}, {1, 3}, {2, 6}, {15, 18}}, [][]int{{1, 6}, {8, 10}, {15, 18}}},
+		{[][]int{{1, 9}, {2, 8}, {3, 7}}, [][]int{{1, 9}}},
+		{[][]int{{5, 6}, {1, 2}, {3, 4}}, [][]int{{1, 2}, {3, 4}, {5, 6}}},
+		{[][]int{{1, 2}, {2, 3}}, [][]int{{1, 3}}},
+		{[][]int{{4, 4}, {1, 4}}, [][]int{{1, 4}}},
+		{nil, [][]int{}},
+	}
+	for _, tt := range tests {
+		in := fmt.Sprint(tt.intervals)
+		if got := MergeIntervals(tt.intervals); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("MergeIntervals(%v) = %v, want %v", in, got, tt.want)
+		}
+		if fmt.Sprint(tt.intervals) != in {
+			t.Errorf("MergeIntervals modified its input %v to %v", in, tt.intervals)
+		}
+	}
+
+	r := rand.New(rand.NewSource(48))
+	for iter := 0; iter < 200; iter++ {
+		intervals := randomIntervals(r)
+		// Points are doubled so that touching intervals share a covered
+		// point and gaps of one unit stay visible.
+		var covered [61]bool
+		for _, iv := range intervals {
+			for x := 2 * iv[0]; x <= 2*iv[1]; x++ {
+				covered[x] = true
+			}
+		}
+		want := [][]int{}
+		for x := 0; x < len(covered); x++ {
+			if covered[x] && (x == 0 || !covered[x-1]) {
+				want = append(want, []int{x / 2, 0})
+			}
+			if covered[x] && (x == len(covered)-1 || !covered[x+1]) {
+				want[len(want)-1][1] = x / 2
+			}
+		}
+		if got := MergeIntervals(intervals); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("MergeIntervals(%v) = %v, want %v", intervals, got, want)
+		}
+	}
+}
+
+func randomIntervals(r *rand.Rand) [][]int {
+	intervals := make([][]int, r.Intn(8))
+	for i := range intervals {
+		start := r.Intn(25)
+		intervals[i] = []int{start, start + 1 + r.Intn(5)}
+	}
+	return intervals
+}
@@ -0,0 +1,113 @@
+package goproject
+
+import "cmp"
+
+// LazyDeleteHeap is a heap that can delete arbitrary values in amortised
+// O(1). A deleted value stays in the underlying heap, counted in pending,
+// until it surfaces at the top, where Peek and Pop discard it. A deleted
+// value that never surfaces, such as one below the maximum on increasing
+// input, is swept out once deleted values outnumber live ones, so the
+// underlying heap holds at most about twice Len. Len counts live values
+// only. The zero LazyDeleteHeap is an empty max-heap for the
+// predeclared ordered types.
+type LazyDeleteHeap[T comparable] struct {
+	h       Heap[T]
+	count   map[T]int // live occurrences of each value
+	pending map[T]int // deleted occurrences still in h
+	stale   int       // sum of pending
+	live    int
+}
+
+// NewLazyDeleteHeap returns an empty max-heap of T configured by opts, e.g.
+// WithMinOrdering for a min-heap.
+func NewLazyDeleteHeap[T cmp.Ordered](opts ...Option) LazyDeleteHeap[T] {
+	return LazyDeleteHeap[T]{h: NewHeapOf[T](opts...)}
+}
+
+// Push adds xs to the heap.
+func (l *LazyDeleteHeap[T]) Push(xs ...T) {
+	if l.count == nil {
+		l.count = make(map[T]int)
+		l.pending = make(map[T]int)
+	}
+	l.h.Push(xs...)
+	for _, x := range xs {
+		l.count[x]++
+	}
+	l.live += len(xs)
+}
+
+// Delete removes one live occurrence of x. Deleting a value more times than
+// it was pushed is a no-op reported as false.
+func (l *LazyDeleteHeap[T]) Delete(x T) bool {
+	if l.count[x] == 0 {
+		return false
+	}
+	if l.count[x]--; l.count[x] == 0 {
+		delete(l.count, x)
+	}
+	l.pending[x]++
+	l.stale++
+	l.live--
+	l.compact()
+	return true
+}
+
+// Peek returns the top live element. ok is false if every element pushed
+// has been popped or deleted.
+func (l *LazyDeleteHeap[T]) Peek() (T, bool) {
+	l.prune()
+	return l.h.Peek()
+}
+
+// Pop removes and returns the top live element; see Peek.
+func (l *LazyDeleteHeap[T]) Pop() (T, bool) {
+	l.prune()
+	x, ok := l.h.Pop()
+	if ok {
+		if l.count[x]--; l.count[x] == 0 {
+			delete(l.count, x)
+		}
+		l.live--
+		l.compact()
+	}
+	return x, ok
+}
+
+// Len returns the number of live elements.
+func (l *LazyDeleteHeap[T]) Len() int {
+	return l.live
+}
+
+// prune discards deleted values from the top of the heap.
+func (l *LazyDeleteHeap[T]) prune() {
+	for {
+		top, ok := l.h.Peek()
+		if !ok || l.pending[top] == 0 {
+			return
+		}
+		if l.pending[top]--; l.pending[top] == 0 {
+			delete(l.pending, top)
+		}
+		l.stale--
+		l.h.Pop()
+	}
+}
+
+// compact rebuilds the heap without its deleted values once they
+// outnumber the live ones. Each rebuild costs O(live + stale) and follows
+// at least live deletions, so deletions stay amortised O(1).
+func (l *LazyDeleteHeap[T]) compact() {
+	if l.stale <= l.live {
+		return
+	}
+	l.h.retain(func(x T) bool {
+		if l.pending[x] == 0 {
+			return true
+		}
+		l.pending[x]--
+		return false
+	})
+	clear(l.pending)
+	l.stale = 0
+}
@@ -0,0 +1,119 @@
+package goproject
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLazyDeleteHeap(t *testing.T) {
+	var l LazyDeleteHeap[int]
+	if _, ok := l.Peek(); ok || l.Delete(3) {
+		t.Fatalf("zero LazyDeleteHeap is not empty")
+	}
+	l.Push(5, 3, 5, 8)
+	if !l.Delete(8) || !l.Delete(5) || l.Len() != 2 {
+		t.Fatalf("Delete of pushed values failed, Len %d", l.Len())
+	}
+	if top, ok := l.Peek(); !ok || top != 5 {
+		t.Fatalf("Peek = (%d, %v), want the remaining 5", top, ok)
+	}
+	if l.Delete(8) || l.Delete(4) {
+		t.Fatalf("Delete of a value with no live occurrence reported true")
+	}
+	if !l.Delete(5) || !l.Delete(3) || l.Delete(5) {
+		t.Fatalf("Delete beyond the pushed count misreported")
+	}
+	if _, ok := l.Peek(); ok || l.Len() != 0 {
+		t.Fatalf("Peek after deleting everything = ok %v, Len %d", ok, l.Len())
+	}
+	if _, ok := l.Pop(); ok {
+		t.Fatalf("Pop after deleting everything reported ok")
+	}
+	if l.h.Len() != 0 {
+		t.Fatalf("underlying heap still holds %d deleted values", l.h.Len())
+	}
+
+	m := NewLazyDeleteHeap[int](WithMinOrdering())
+	m.Push(4, 1, 7)
+	m.Delete(1)
+	if x, _ := m.Pop(); x != 4 || m.Len() != 1 {
+		t.Fatalf("min LazyDeleteHeap Pop = %d, Len %d", x, m.Len())
+	}
+}
+
+func TestLazyDeleteHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(34))
+	var l LazyDeleteHeap[int]
+	counts := map[int]int{}
+	for op := 0; op < 3000; op++ {
+		x := r.Intn(15)
+		switch r.Intn(4) {
+		case 0, 1:
+			l.Push(x)
+			counts[x]++
+		case 2:
+			if got := l.Delete(x); got != (counts[x] > 0) {
+				t.Fatalf("Delete(%d) = %v with %d live", x, got, counts[x])
+			}
+			counts[x] = max(counts[x]-1, 0)
+		case 3:
+			if got, ok := l.Pop(); ok {
+				counts[got]--
+			}
+		}
+		want, n := -1, 0
+		for v, c := range counts {
+			n += c
+			if c > 0 && v > want {
+				want = v
+			}
+		}
+		top, ok := l.Peek()
+		if l.Len() != n || ok != (n > 0) || ok && top != want {
+			t.Fatalf("Peek = (%d, %v), Len %d; want (%d, %v), %d", top, ok, l.Len(), want, n > 0, n)
+		}
+	}
+}
+
+func TestSlidingWindowHeapSize(t *testing.T) {
+	nums := []int{9, 10, 9, -7, -4, 8, 2, -6, 9, 9, 1}
+	const k = 4
+	w := NewLazyDeleteHeap[int](WithCapacity(k))
+	w.Push(nums[:k]...)
+	for i := k; i < len(nums); i++ {
+		w.Delete(nums[i-k])
+		w.Push(nums[i])
+		w.Peek()
+		if w.Len() != k {
+			t.Fatalf("window ending at %d has %d live elements, want %d", i, w.Len(), k)
+		}
+		if w.h.Len() > 2*k+1 {
+			t.Fatalf("heap holds %d elements for a window of %d", w.h.Len(), k)
+		}
+	}
+
+	// Values deleted below the top never surface on their own: increasing
+	// input for a max-heap, decreasing for a min-heap.
+	for _, opts := range [][]Option{nil, {WithMinOrdering()}, {WithStable()}} {
+		w := NewLazyDeleteHeap[int](opts...)
+		step := 1
+		if len(opts) > 0 && collectOptions(opts).min {
+			step = -1
+		}
+		for i := 0; i < 100000; i++ {
+			w.Push(i * step)
+			if i >= k {
+				w.Delete((i - k) * step)
+			}
+			if top, _ := w.Peek(); top != i*step {
+				t.Fatalf("window ending at %d has top %d", i, top)
+			}
+			if w.h.Len() > 2*k+1 {
+				t.Fatalf("after %d values the heap holds %d elements for a window of %d", i+1, w.h.Len(), k)
+			}
+		}
+		if w.Len() != k {
+			t.Fatalf("Len = %d, want %d", w.Len(), k)
+		}
+	}
+}
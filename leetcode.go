@@ -1,116 +1,935 @@
 package goproject
 
 import (
+	"cmp"
 	"fmt"
-	"testing"
+	"iter"
+	"strings"
 )
+
+// maxSlidingWindow is MaxSlidingWindow for ints.
 func maxSlidingWindow(nums []int, k int) []int {
-	res:=make([]int,0,1)
-	h:=NewHeap()
-	for i:=0;i<k;i++{
-		h.Push(nums[i])
-	}
-	for i:=0;i+k-1<len(nums);i++{
-		if i==0{
-			res=append(res,h.Peek())
-			continue
-		}
-		if h.Peek()==nums[i-1]{
-			fmt.Printf("zhaoxiangyu %+v %+v \n",h.Peek(),i-1)
-			h.Pop()
+	return MaxSlidingWindow(nums, k)
+}
+
+// MaxSlidingWindow returns the maximum of every window of k consecutive
+// elements of nums. It returns an empty slice when there is no full window,
+// i.e. when k <= 0 or k > len(nums). Floating-point input must not contain
+// NaNs; MaxSlidingWindowFloat checks for them.
+func MaxSlidingWindow[T cmp.Ordered](nums []T, k int) []T {
+	return slidingWindowTop(nums, k)
+}
+
+// slidingWindowTop returns the top of every window of k consecutive
+// elements of nums under the ordering chosen by opts: the maximum by
+// default, the minimum with WithMinOrdering. Leaving values are deleted
+// lazily from a LazyDeleteHeap, which never holds more than 2k of them.
+func slidingWindowTop[T cmp.Ordered](nums []T, k int, opts ...Option) []T {
+	if k <= 0 || k > len(nums) {
+		return []T{}
+	}
+	res := make([]T, 0, len(nums)-k+1)
+	w := NewLazyDeleteHeap[T](append(opts, WithCapacity(2*k))...)
+	w.Push(nums[:k]...)
+	for i := 0; i+k-1 < len(nums); i++ {
+		if i > 0 {
+			w.Delete(nums[i-1])
+			w.Push(nums[i+k-1])
 		}
-		h.Push(nums[i+k-1])
-		res=append(res,h.Peek())
+		top, _ := w.Peek()
+		res = append(res, top)
+	}
+	return res
+}
+
+// Heap is a binary heap, or d-ary with WithArity, ordered by a less
+// function. Heaps built from the cmp.Ordered constructors keep their largest
+// element on top unless built with one of the min constructors or
+// WithMinOrdering; floating-point NaNs are not ordered and must not be
+// pushed to those. The zero Heap is an empty max-heap for the predeclared
+// ordered types.
+type Heap[T any] struct {
+	c      []T
+	less   func(a, b T) bool
+	tracer func(event string, state []T)
+	arity  int // children per node; 0 means 2
+
+	counting bool // set by WithStats
+	stats    HeapStats
+
+	// seq holds, for stable heaps only, the insertion number of each
+	// element of c; next is the number the next pushed element gets.
+	stable bool
+	seq    []uint64
+	next   uint64
+
+	// moved, if set, is told an element's new index whenever any operation
+	// places it in c, and -1 when it leaves the heap, so a position map fed
+	// by it always matches c. IndexedHeap uses it to keep its key
+	// positions current.
+	moved func(x T, i int)
+}
+
+// Option configures a heap built by one of the New constructors.
+type Option func(*heapOptions)
+
+type heapOptions struct {
+	stable   bool
+	arity    int
+	capacity int
+	min      bool
+	stats    bool
+}
+
+func collectOptions(opts []Option) heapOptions {
+	var o heapOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithStable makes elements that compare equal pop in the order they were
+// pushed. It costs an extra uint64 per element.
+func WithStable() Option {
+	return func(o *heapOptions) { o.stable = true }
+}
+
+// WithArity gives every node d children instead of two. Wider heaps are
+// shallower, so Pop touches fewer cache lines on large heaps at the cost of
+// more comparisons per level. A d below 2 means 2.
+func WithArity(d int) Option {
+	return func(o *heapOptions) { o.arity = max(d, 2) }
+}
+
+// WithCapacity preallocates room for n elements so that filling the heap
+// up to n does not grow its backing slice.
+func WithCapacity(n int) Option {
+	return func(o *heapOptions) { o.capacity = max(n, 0) }
+}
+
+// WithStats makes the heap count its work; see Stats. Without it the
+// counters cost a single untaken branch.
+func WithStats() Option {
+	return func(o *heapOptions) { o.stats = true }
+}
+
+// WithMinOrdering makes NewHeap, NewHeapOf, NewIndexedHeap and
+// NewLazyDeleteHeap keep their smallest element on top, like the Min
+// constructors. It has no effect on NewHeapFunc, whose less already fixes
+// the order.
+func WithMinOrdering() Option {
+	return func(o *heapOptions) { o.min = true }
+}
+
+// IntHeap is the int heap used by maxSlidingWindow and friends.
+type IntHeap = Heap[int]
+
+// NewHeap returns an empty int max-heap configured by opts, e.g.
+// NewHeap(WithCapacity(k), WithMinOrdering()).
+func NewHeap(opts ...Option) IntHeap {
+	return NewHeapOf[int](opts...)
+}
+
+// NewHeapWithCapacity returns an empty int max-heap whose backing slice can
+// hold capacity elements before it has to grow.
+func NewHeapWithCapacity(capacity int) IntHeap {
+	return NewHeap(WithCapacity(capacity))
+}
+
+// NewMinHeap returns an int heap that keeps its smallest element on top.
+func NewMinHeap(opts ...Option) IntHeap {
+	return NewMinHeapOf[int](opts...)
+}
+
+// NewHeapOf returns an empty max-heap of T, or a min-heap with
+// WithMinOrdering.
+func NewHeapOf[T cmp.Ordered](opts ...Option) Heap[T] {
+	if collectOptions(opts).min {
+		return newHeap(1, cmp.Less[T], opts...)
 	}
+	return newHeap(1, greater[T], opts...)
+}
+
+// NewMinHeapOf returns an empty min-heap of T.
+func NewMinHeapOf[T cmp.Ordered](opts ...Option) Heap[T] {
+	return newHeap(1, cmp.Less[T], opts...)
+}
+
+// NewHeapFunc returns an empty heap ordered entirely by less: less(a, b)
+// reports whether a has higher priority than b, i.e. whether a should be
+// popped first. Passing a "<" comparison therefore yields a min-heap. A nil
+// less gives the usual max-heap for the predeclared ordered types and panics
+// for any other T.
+func NewHeapFunc[T any](less func(a, b T) bool, opts ...Option) Heap[T] {
+	if less == nil {
+		less = mustNaturalGreater[T]()
+	}
+	return newHeap(1, less, opts...)
+}
+
+// Heapify returns a max-heap holding a copy of xs, built bottom-up in O(n).
+func Heapify[T cmp.Ordered](xs []T) Heap[T] {
+	return NewHeapFromSlice(append([]T(nil), xs...))
+}
+
+// NewHeapFromSlice turns xs into a max-heap in O(n). The heap takes
+// ownership of xs: it is reordered in place and must not be used by the
+// caller afterwards. Use Heapify to leave xs untouched.
+func NewHeapFromSlice[T cmp.Ordered](xs []T) Heap[T] {
+	h := Heap[T]{c: xs, less: greater[T]}
+	h.heapify()
+	return h
+}
+
+// HeapSort returns a sorted (ascending) copy of xs.
+func HeapSort[T cmp.Ordered](xs []T) []T {
+	res := append([]T(nil), xs...)
+	HeapSortInPlace(res, false)
 	return res
 }
 
-type Heap struct {
-	c []int
+// HeapSortInPlace sorts xs in O(n log n) without allocating, ascending or,
+// if descending is set, descending. It heapifies xs with the opposite
+// ordering and repeatedly swaps the top behind the shrinking heap prefix.
+func HeapSortInPlace[T cmp.Ordered](xs []T, descending bool) {
+	h := Heap[T]{c: xs, less: greater[T]}
+	if descending {
+		h.less = cmp.Less[T]
+	}
+	h.heapify()
+	for n := len(xs) - 1; n > 0; n-- {
+		h.swap(0, n)
+		h.c = h.c[:n]
+		h.siftDown(0)
+	}
 }
 
-func NewHeap() Heap {
-	h:=Heap{c:make([]int,0,1)}
+// newHeap returns an empty heap ordered by less with room for capacity
+// elements, unless WithCapacity says otherwise.
+func newHeap[T any](capacity int, less func(a, b T) bool, opts ...Option) Heap[T] {
+	o := collectOptions(opts)
+	if o.capacity > 0 {
+		capacity = o.capacity
+	}
+	h := Heap[T]{c: make([]T, 0, capacity), less: less, stable: o.stable, arity: o.arity, counting: o.stats}
+	if h.stable {
+		h.seq = make([]uint64, 0, capacity)
+	}
 	return h
 }
 
-func (h *Heap) Push(x int) {
-	//fmt.Println("enter")
-	h.c = append(h.c,x)
-	idx:=len(h.c)-1
-	for idx -1>=0 && h.c[(idx-1)/2]< h.c[idx]{
-		h.c[(idx-1)/2],h.c[idx]=h.c[idx],h.c[(idx-1)/2]
-		idx = (idx-1)/2
-	}
-	//fmt.Println(h.c)
-}
-
-func (h *Heap) Pop() int{
-	fmt.Println("FuncIn")
-	res := -1
-	if !h.IsEmpty(){
-		res= h.c[0]
-		h.c[0]=h.c[len(h.c)-1]
-		h.c=h.c[:len(h.c)-1]
-		idx:=0
-		for idx<len(h.c) {
-			left:=idx*2+1
-			right:=idx*2+2
-			if right >= len(h.c) && left >= len(h.c) {
-				fmt.Println(h.c)
-				fmt.Println(idx)
-				fmt.Println("FuncOut1")
-				return res
-			}
-			if right >= len(h.c) && h.c[idx] < h.c[left] {
-				h.c[idx], h.c[left] = h.c[left], h.c[idx]
-				idx = left
-				continue
+func greater[T cmp.Ordered](a, b T) bool {
+	return a > b
+}
+
+// mustNaturalGreater returns greater[T] when T is one of the predeclared
+// ordered types. Named types such as time.Duration have to bring their own
+// comparator.
+func mustNaturalGreater[T any]() func(a, b T) bool {
+	var f any
+	switch any(*new(T)).(type) {
+	case int:
+		f = greater[int]
+	case int8:
+		f = greater[int8]
+	case int16:
+		f = greater[int16]
+	case int32:
+		f = greater[int32]
+	case int64:
+		f = greater[int64]
+	case uint:
+		f = greater[uint]
+	case uint8:
+		f = greater[uint8]
+	case uint16:
+		f = greater[uint16]
+	case uint32:
+		f = greater[uint32]
+	case uint64:
+		f = greater[uint64]
+	case uintptr:
+		f = greater[uintptr]
+	case float32:
+		f = greater[float32]
+	case float64:
+		f = greater[float64]
+	case string:
+		f = greater[string]
+	}
+	less, ok := f.(func(a, b T) bool)
+	if !ok {
+		panic(fmt.Sprintf("goproject: heap of %T needs a less function", *new(T)))
+	}
+	return less
+}
+
+// above reports whether a belongs closer to the root than b. A zero Heap has
+// no comparator yet and falls back to max ordering.
+func (h *Heap[T]) above(a, b T) bool {
+	if h.less == nil {
+		h.less = mustNaturalGreater[T]()
+	}
+	if h.counting {
+		h.stats.Comparisons++
+	}
+	return h.less(a, b)
+}
+
+// higher reports whether the element at i belongs closer to the root than
+// the one at j. Stable heaps break ties by insertion order.
+func (h *Heap[T]) higher(i, j int) bool {
+	if h.above(h.c[i], h.c[j]) {
+		return true
+	}
+	return h.stable && h.seq[i] < h.seq[j] && !h.above(h.c[j], h.c[i])
+}
+
+func (h *Heap[T]) swap(i, j int) {
+	if h.counting {
+		h.stats.Swaps++
+	}
+	h.c[i], h.c[j] = h.c[j], h.c[i]
+	if h.stable {
+		h.seq[i], h.seq[j] = h.seq[j], h.seq[i]
+	}
+	if h.moved != nil {
+		h.moved(h.c[i], i)
+		h.moved(h.c[j], j)
+	}
+}
+
+// add appends x to the backing slice without restoring the heap.
+func (h *Heap[T]) add(x T) {
+	h.c = append(h.c, x)
+	if h.stable {
+		h.seq = append(h.seq, h.next)
+		h.next++
+	}
+	if h.moved != nil {
+		h.moved(x, len(h.c)-1)
+	}
+}
+
+// truncate shortens the heap to n elements, zeroing the dropped slots so
+// the backing array does not keep them alive.
+func (h *Heap[T]) truncate(n int) {
+	if h.moved != nil {
+		for _, x := range h.c[n:] {
+			h.moved(x, -1)
+		}
+	}
+	h.shrink(n)
+}
+
+// shrink is truncate without telling moved, for callers that have already
+// accounted for the dropped slots.
+func (h *Heap[T]) shrink(n int) {
+	clear(h.c[n:])
+	h.c = h.c[:n]
+	if h.stable {
+		h.seq = h.seq[:n]
+	}
+}
+
+// setRoot overwrites the top element with a newly pushed x.
+func (h *Heap[T]) setRoot(x T) {
+	if h.moved != nil {
+		h.moved(h.c[0], -1)
+		h.moved(x, 0)
+	}
+	h.c[0] = x
+	if h.stable {
+		h.seq[0] = h.next
+		h.next++
+	}
+}
+
+// rootBefore reports whether the current top would pop before a newly
+// pushed x. On a stable heap the top wins ties, being older.
+func (h *Heap[T]) rootBefore(x T) bool {
+	if h.stable {
+		return !h.above(x, h.c[0])
+	}
+	return h.above(h.c[0], x)
+}
+
+// d returns the number of children per node.
+func (h *Heap[T]) d() int {
+	if h.arity == 0 {
+		return 2
+	}
+	return h.arity
+}
+
+func (h *Heap[T]) parent(i int) int {
+	return (i - 1) / h.d()
+}
+
+func (h *Heap[T]) firstChild(i int) int {
+	return i*h.d() + 1
+}
+
+func (h *Heap[T]) siftUp(idx int) {
+	for idx > 0 {
+		parent := h.parent(idx)
+		if !h.higher(idx, parent) {
+			return
+		}
+		h.swap(parent, idx)
+		idx = parent
+	}
+}
+
+func (h *Heap[T]) siftDown(idx int) {
+	for {
+		first := h.firstChild(idx)
+		if first >= h.Len() {
+			return
+		}
+		// Descend towards the child that belongs highest; the last node
+		// may have fewer than d children.
+		child := first
+		for c := first + 1; c < min(first+h.d(), h.Len()); c++ {
+			if h.higher(c, child) {
+				child = c
 			}
-			if h.c[idx]>=h.c[left] && h.c[idx]>=h.c[right]{
-				fmt.Println(h.c)
-				fmt.Printf("zhaoxiangyu %+v %+v %+v \n",idx,h.c[idx],h.c[left])
-				fmt.Println(res)
-				fmt.Println("FuncOut2")
-				return res
+		}
+		if !h.higher(child, idx) {
+			return
+		}
+		h.swap(idx, child)
+		idx = child
+	}
+}
+
+// fix moves the element at idx up or down, whichever restores the heap.
+func (h *Heap[T]) fix(idx int) {
+	if idx > 0 && h.higher(idx, h.parent(idx)) {
+		h.siftUp(idx)
+	} else {
+		h.siftDown(idx)
+	}
+}
+
+// heapify restores the heap property over the whole backing slice.
+func (h *Heap[T]) heapify() {
+	if h.Len() < 2 {
+		return
+	}
+	for i := h.parent(h.Len() - 1); i >= 0; i-- {
+		h.siftDown(i)
+	}
+}
+
+// Push adds xs to the heap. A batch at least as large as the heap it is
+// pushed onto is appended and the whole heap rebuilt in O(n+m); smaller
+// batches are sifted up one element at a time in O(m log(n+m)).
+func (h *Heap[T]) Push(xs ...T) {
+	if len(xs) == 0 {
+		return
+	}
+	if h.counting {
+		h.stats.Pushes += uint64(len(xs))
+	}
+	if len(xs) > 1 && len(xs) >= h.Len() {
+		h.pushRebuild(xs)
+	} else {
+		h.pushEach(xs)
+	}
+	h.trace("push")
+}
+
+// PushAll is Push spelled for batch inserts; it makes the same choice
+// between per-element sift-up and a full rebuild.
+func (h *Heap[T]) PushAll(xs ...T) {
+	h.Push(xs...)
+}
+
+func (h *Heap[T]) pushEach(xs []T) {
+	for _, x := range xs {
+		h.add(x)
+		h.siftUp(h.Len() - 1)
+	}
+}
+
+func (h *Heap[T]) pushRebuild(xs []T) {
+	for _, x := range xs {
+		h.add(x)
+	}
+	h.heapify()
+}
+
+// Pop removes and returns the top element. ok is false if the heap was
+// empty.
+func (h *Heap[T]) Pop() (res T, ok bool) {
+	if !h.IsEmpty() {
+		res, ok = h.removeAt(0), true
+		if h.counting {
+			h.stats.Pops++
+		}
+		h.trace("pop")
+	}
+	return res, ok
+}
+
+// PushPop pushes x and then pops the top element, using at most one
+// sift-down. If x would itself be on top it is returned straight away and
+// the heap is left untouched.
+func (h *Heap[T]) PushPop(x T) T {
+	if h.counting {
+		h.stats.Pushes++
+		h.stats.Pops++
+	}
+	if h.IsEmpty() || !h.rootBefore(x) {
+		return x
+	}
+	top := h.c[0]
+	h.setRoot(x)
+	h.siftDown(0)
+	h.trace("pushpop")
+	return top
+}
+
+// Replace pops the top element and pushes x with a single sift-down,
+// returning the old top and true. On an empty heap it just pushes x and
+// returns false. Unlike PushPop, the old top is returned even when x ranks
+// above it, so it need not be >= x in a max-heap.
+func (h *Heap[T]) Replace(x T) (T, bool) {
+	if h.IsEmpty() {
+		h.Push(x)
+		var zero T
+		return zero, false
+	}
+	if h.counting {
+		h.stats.Pushes++
+		h.stats.Pops++
+	}
+	top := h.c[0]
+	h.setRoot(x)
+	h.siftDown(0)
+	h.trace("replace")
+	return top, true
+}
+
+// Merge moves every element of other into h and leaves other empty. The
+// combined heap is rebuilt in O(n+m) using h's ordering. Merging a heap with
+// itself is a no-op.
+func (h *Heap[T]) Merge(other *Heap[T]) {
+	if other == h || other.IsEmpty() {
+		return
+	}
+	// A stable heap keeps other's insertion order by offsetting its
+	// sequence numbers past everything already in h.
+	for i, x := range other.c {
+		h.c = append(h.c, x)
+		if h.moved != nil {
+			h.moved(x, len(h.c)-1)
+		}
+		if h.stable {
+			n := uint64(i)
+			if other.stable {
+				n = other.seq[i]
 			}
-			if h.c[idx]>=h.c[left]{
-				h.c[idx], h.c[right] = h.c[right], h.c[idx]
-				idx = right
-				continue
+			h.seq = append(h.seq, h.next+n)
+		}
+	}
+	if h.stable {
+		h.next += max(other.next, uint64(other.Len()))
+	}
+	other.truncate(0)
+	h.heapify()
+	h.trace("merge")
+	other.trace("merge")
+}
+
+// Clone returns an independent copy of h with its own backing slice and the
+// same ordering. The clone starts without a tracer and with zeroed Stats.
+func (h *Heap[T]) Clone() Heap[T] {
+	c := Heap[T]{c: append(make([]T, 0, h.Len()), h.c...), less: h.less, arity: h.arity, counting: h.counting, stable: h.stable, next: h.next}
+	if h.stable {
+		c.seq = append(make([]uint64, 0, h.Len()), h.seq...)
+	}
+	return c
+}
+
+// Clear removes every element but keeps the backing array, so refilling the
+// heap up to its previous capacity does not allocate.
+func (h *Heap[T]) Clear() {
+	h.truncate(0)
+}
+
+// ClearAndRelease removes every element and drops the backing array so its
+// memory can be reclaimed, e.g. after a one-off spike in size.
+func (h *Heap[T]) ClearAndRelease() {
+	h.truncate(0)
+	h.c, h.seq = nil, nil
+}
+
+// Compact reallocates the backing array down to Len elements if it has
+// grown to more than twice that, releasing memory left over from a burst.
+// Pop never shrinks the array on its own, so heaps that are emptied and
+// refilled keep their capacity.
+func (h *Heap[T]) Compact() {
+	if cap(h.c) > 2*h.Len() {
+		h.c = append(make([]T, 0, h.Len()), h.c...)
+		if h.stable {
+			h.seq = append(make([]uint64, 0, h.Len()), h.seq...)
+		}
+	}
+}
+
+// Reset empties the heap and replaces its backing array with a fresh one of
+// the given capacity.
+func (h *Heap[T]) Reset(capacity int) {
+	h.truncate(0)
+	h.c = make([]T, 0, capacity)
+	if h.stable {
+		h.seq = make([]uint64, 0, capacity)
+	}
+}
+
+// Values returns a copy of the elements in heap (level) order. Apart from
+// the first being the top, the order is unspecified. Changing the copy does
+// not affect the heap.
+func (h *Heap[T]) Values() []T {
+	return append(make([]T, 0, h.Len()), h.c...)
+}
+
+// Sorted returns the elements in pop order, leaving the heap intact.
+func (h *Heap[T]) Sorted() []T {
+	c := h.Clone()
+	return c.Drain()
+}
+
+// Each calls fn on the elements in pop order until fn returns false. It
+// pops from a copy, so the heap is left intact and stopping early only costs
+// what was visited.
+func (h *Heap[T]) Each(fn func(T) bool) {
+	h.Descend()(fn)
+}
+
+// All returns an iterator over the elements in heap (level) order, as
+// Values would list them, without copying or modifying the heap. The heap
+// must not be changed during the iteration.
+func (h *Heap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range h.c {
+			if !yield(x) {
+				return
 			}
-			if h.c[idx]>=h.c[right]{
-				h.c[idx], h.c[left] = h.c[left], h.c[idx]
-				idx = left
-				continue
+		}
+	}
+}
+
+// Descend returns an iterator over the elements in pop order, largest first
+// for a max-heap. Each iteration pops from its own copy taken when it
+// starts, so the heap is left intact and breaking out early is safe.
+func (h *Heap[T]) Descend() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		c := h.Clone()
+		for !c.IsEmpty() {
+			x, _ := c.Pop()
+			if !yield(x) {
+				return
 			}
-			if h.c[left]<h.c[right]{
-				h.c[idx], h.c[right] = h.c[right], h.c[idx]
-				idx = right
-				continue
-			}else {
-				h.c[idx], h.c[left] = h.c[left], h.c[idx]
-				idx = left
-				continue
+		}
+	}
+}
+
+// PeekN returns the top k elements in pop order without modifying the
+// heap. It walks the heap with an auxiliary heap of at most k(d-1)+1
+// candidate indices for arity d, so it costs O(kd log(kd)), O(k log k) for a
+// binary heap, however large the heap is. It returns an empty slice for
+// k <= 0 and every element when k exceeds Len.
+func (h *Heap[T]) PeekN(k int) []T {
+	k = max(0, min(k, h.Len()))
+	res := make([]T, 0, k)
+	h.visitTop(k, func(i int) {
+		res = append(res, h.c[i])
+	})
+	return res
+}
+
+// PeekK is PeekN that also reports whether the heap held k elements: ok is
+// false, with every element returned, if k exceeds Len, and false with an
+// empty slice if k is negative.
+func (h *Heap[T]) PeekK(k int) ([]T, bool) {
+	return h.PeekN(k), k >= 0 && k <= h.Len()
+}
+
+// Kth returns the k-th element in pop order (k = 1 is Peek) without
+// modifying the heap; equal elements count individually. Like PeekN it only
+// descends into children that can still hold one of the top k, costing
+// O(kd log(kd)) for arity d. ok is false if k is not in [1, Len].
+func (h *Heap[T]) Kth(k int) (res T, ok bool) {
+	if k < 1 || k > h.Len() {
+		return res, false
+	}
+	h.visitTop(k, func(i int) {
+		res = h.c[i]
+	})
+	return res, true
+}
+
+// visitTop calls fn on the indices of the top k elements in pop order,
+// where 0 <= k <= Len. A node can only be among the top k once its parent
+// has been visited, and each visit swaps one pending index for at most d
+// children, so a frontier heap of at most k(d-1)+1 pending indices
+// suffices.
+func (h *Heap[T]) visitTop(k int, fn func(i int)) {
+	if k == 0 {
+		return
+	}
+	frontier := newHeap(min(k*(h.d()-1)+1, h.Len()), h.higher)
+	frontier.Push(0)
+	for n := 0; n < k; n++ {
+		i, _ := frontier.Pop()
+		fn(i)
+		first := h.firstChild(i)
+		for child := first; child < min(first+h.d(), h.Len()); child++ {
+			frontier.Push(child)
+		}
+	}
+}
+
+// PopK pops up to k elements and returns them in pop order. It returns an
+// empty slice for k <= 0 and everything left when k exceeds Len.
+func (h *Heap[T]) PopK(k int) []T {
+	k = max(0, min(k, h.Len()))
+	res := make([]T, 0, k)
+	for len(res) < k {
+		x, _ := h.Pop()
+		res = append(res, x)
+	}
+	return res
+}
+
+// Drain empties the heap and returns its elements in the order Pop would
+// have produced them: descending for a max-heap, ascending for a min-heap.
+// The heap stays usable afterwards.
+func (h *Heap[T]) Drain() []T {
+	res := make([]T, 0, h.Len())
+	for !h.IsEmpty() {
+		x, _ := h.Pop()
+		res = append(res, x)
+	}
+	return res
+}
+
+// RemoveAt removes and returns the element at index i of the backing slice
+// (as seen by StdHeapAdapter or decoded JSON). ok is false if i is out of
+// range.
+func (h *Heap[T]) RemoveAt(i int) (res T, ok bool) {
+	if i < 0 || i >= h.Len() {
+		return res, false
+	}
+	res = h.removeAt(i)
+	h.trace("removeat")
+	return res, true
+}
+
+// RemoveFunc removes the first element, in backing-slice order, for which
+// match returns true, scanning in O(n) and restoring the heap in O(log n).
+// It reports false if nothing matched.
+func (h *Heap[T]) RemoveFunc(match func(T) bool) bool {
+	for i, x := range h.c {
+		if match(x) {
+			h.removeAt(i)
+			h.trace("removefunc")
+			return true
+		}
+	}
+	return false
+}
+
+// RemoveValue removes one occurrence of x from h; see RemoveFunc. It is a
+// function rather than a method because it needs T to be comparable.
+func RemoveValue[T comparable](h *Heap[T], x T) bool {
+	return h.RemoveFunc(func(y T) bool { return y == x })
+}
+
+// retain drops every element for which keep returns false, calling it
+// once per element in backing order, and rebuilds the heap in O(n).
+func (h *Heap[T]) retain(keep func(T) bool) {
+	n := 0
+	for i, x := range h.c {
+		if !keep(x) {
+			if h.moved != nil {
+				h.moved(x, -1)
 			}
+			continue
+		}
+		h.c[n] = x
+		if h.stable {
+			h.seq[n] = h.seq[i]
+		}
+		if h.moved != nil && n != i {
+			h.moved(x, n)
+		}
+		n++
+	}
+	h.shrink(n)
+	h.heapify()
+}
+
+// ContainsFunc reports whether any element satisfies match, scanning the
+// heap in O(n). IndexedHeap.Contains answers the same for keys in O(1).
+func (h *Heap[T]) ContainsFunc(match func(T) bool) bool {
+	for _, x := range h.c {
+		if match(x) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsValue reports whether x is in h, in O(n); see ContainsFunc.
+func ContainsValue[T comparable](h *Heap[T], x T) bool {
+	return h.ContainsFunc(func(y T) bool { return y == x })
+}
+
+// Equal reports whether h and other hold the same multiset of elements,
+// however their backing slices are arranged. Elements are compared under
+// h's ordering: a and b match when neither comes before the other, which
+// for the predeclared ordered types is ==, but for a NewHeapFunc heap may
+// equate distinct values that tie. The heaps should share an ordering.
+// Equal takes O(n log n) and leaves both heaps intact.
+func (h *Heap[T]) Equal(other *Heap[T]) bool {
+	if h.Len() != other.Len() {
+		return false
+	}
+	less := h.less
+	if less == nil {
+		less = mustNaturalGreater[T]()
+	}
+	xs, ys := h.Sorted(), other.Sorted()
+	for i := range xs {
+		if less(xs[i], ys[i]) || less(ys[i], xs[i]) {
+			return false
 		}
 	}
-	fmt.Println(h.c)
-	fmt.Println("FuncOut")
+	return true
+}
+
+// removeAt moves the last element into slot i and restores the heap around
+// it. The vacated slot is zeroed so the backing array does not keep popped
+// values alive.
+func (h *Heap[T]) removeAt(i int) T {
+	res := h.c[i]
+	last := h.Len() - 1
+	h.swap(i, last)
+	h.truncate(last)
+	if i < last {
+		h.fix(i)
+	}
 	return res
 }
 
-func (h *Heap) IsEmpty() bool {
-	return len(h.c)==0
+func (h *Heap[T]) IsEmpty() bool {
+	return h.Len() == 0
+}
+
+// Len returns the number of elements in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.c)
+}
+
+// Size is the same as Len.
+func (h *Heap[T]) Size() int {
+	return h.Len()
+}
+
+// Peek returns the top element without removing it. ok is false if the
+// heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if !h.IsEmpty() {
+		return h.c[0], true
+	}
+	var zero T
+	return zero, false
 }
-func (h *Heap) Peek() int{
-	if !h.IsEmpty(){
-		return h.c[0]
+
+// CheckInvariant verifies in O(n) that no element ranks above its parent
+// and reports the first index where that fails.
+func (h *Heap[T]) CheckInvariant() error {
+	for i := 1; i < h.Len(); i++ {
+		if parent := h.parent(i); h.higher(i, parent) {
+			return fmt.Errorf("heap invariant broken at index %d: %v ranks above its parent %v at %d", i, h.c[i], h.c[parent], parent)
+		}
 	}
-	return -1
+	return nil
 }
 
-func Test_Func2(t *testing.T)  {
-	nums:=[]int{9,10,9,-7,-4,8,2,-6}
-	fmt.Println(maxSlidingWindow(nums,5))
+// IsValid reports whether the heap property holds; see CheckInvariant.
+func (h *Heap[T]) IsValid() bool {
+	return h.CheckInvariant() == nil
+}
+
+// maxStringLevels caps how many tree levels String renders.
+const maxStringLevels = 6
+
+// String renders the heap in level order with levels separated by "|", e.g.
+// "[9 | 7 5 | 1 3 2]". Levels below maxStringLevels are summarised by a
+// count.
+func (h Heap[T]) String() string {
+	var b strings.Builder
+	b.WriteByte('[')
+	start, width := 0, 1
+	for level := 0; start < len(h.c); level++ {
+		if level == maxStringLevels {
+			fmt.Fprintf(&b, " | ... %d more", len(h.c)-start)
+			break
+		}
+		if level > 0 {
+			b.WriteString(" |")
+		}
+		for i := start; i < min(start+width, len(h.c)); i++ {
+			if i > 0 {
+				b.WriteByte(' ')
+			}
+			fmt.Fprint(&b, h.c[i])
+		}
+		start, width = start+width, width*h.d()
+	}
+	b.WriteByte(']')
+	return b.String()
+}
+
+// HeapStats counts the work done by a heap built WithStats. Comparisons
+// counts calls to its less function and Swaps element exchanges. Pushes and
+// Pops count elements passed to Push and PushAll and returned by Pop, with
+// PushPop and Replace counting as one of each; Merge, RemoveAt and Clear are
+// not counted.
+type HeapStats struct {
+	Comparisons uint64
+	Swaps       uint64
+	Pushes      uint64
+	Pops        uint64
+}
+
+// Stats returns the counters accumulated since the heap was built or
+// ResetStats was last called. They stay zero unless the heap was built
+// WithStats.
+func (h *Heap[T]) Stats() HeapStats {
+	return h.stats
+}
+
+// ResetStats zeroes the counters.
+func (h *Heap[T]) ResetStats() {
+	h.stats = HeapStats{}
+}
+
+// SetTracer installs fn to be called after every operation that changes the
+// heap, with the method name in lower case ("push", "pop", ...) and a copy of the backing
+// slice in heap order. A nil fn, the default, turns tracing off.
+func (h *Heap[T]) SetTracer(fn func(event string, state []T)) {
+	h.tracer = fn
+}
+
+func (h *Heap[T]) trace(event string) {
+	if h.tracer != nil {
+		h.tracer(event, h.Values())
+	}
 }
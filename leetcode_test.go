@@ -0,0 +1,1902 @@
+package goproject
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestHeapPopOrder(t *testing.T) {
+	tests := []struct {
+		name string
+		nums []int
+	}{
+		{"empty", nil},
+		{"single", []int{7}},
+		{"two", []int{1, 2}},
+		{"duplicates", []int{3, 3, 1, 3, 2, 2}},
+		{"descending", []int{9, 8, 7, 6, 5, 4, 3}},
+		{"ascending", []int{1, 2, 3, 4, 5, 6, 7, 8}},
+		{"negatives", []int{9, 10, 9, -7, -4, 8, 2, -6}},
+	}
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		tests = append(tests, struct {
+			name string
+			nums []int
+		}{"random", r.Perm(1 + r.Intn(64))})
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeap()
+			for _, x := range tt.nums {
+				h.Push(x)
+			}
+			prev := 0
+			for i := range tt.nums {
+				x, ok := h.Pop()
+				if !ok {
+					t.Fatalf("pop %d reported an empty heap", i)
+				}
+				if i > 0 && x > prev {
+					t.Fatalf("pop %d returned %d after %d", i, x, prev)
+				}
+				prev = x
+			}
+			if !h.IsEmpty() {
+				t.Fatalf("heap not empty after popping every element")
+			}
+		})
+	}
+}
+
+func TestHeapSiftEdgeCases(t *testing.T) {
+	for _, pair := range [][2]int{{1, 2}, {2, 1}, {3, 3}} {
+		h := NewHeap()
+		h.Push(pair[0], pair[1])
+		hi, lo := max(pair[0], pair[1]), min(pair[0], pair[1])
+		if got := h.Drain(); !equalInts(got, []int{hi, lo}) {
+			t.Errorf("two-element heap %v drains %v", pair, got)
+		}
+	}
+
+	// Both children equal, larger and smaller than the element sifted down.
+	for _, c := range [][]int{{9, 5, 5, 1}, {9, 5, 5, 7}, {9, 5, 5, 5}, {9, 5, 5, 4, 4, 4, 4}} {
+		h := NewHeapFromSlice(append([]int(nil), c...))
+		h.Pop()
+		assertHeap(t, &h)
+	}
+
+	ptrs := NewHeapFunc(func(a, b *int) bool { return *a > *b })
+	one, two := 1, 2
+	ptrs.Push(&one, &two)
+	ptrs.Pop()
+	if ptrs.c[:2][1] != nil {
+		t.Fatalf("Pop left a reference in the vacated slot")
+	}
+
+	r := rand.New(rand.NewSource(23))
+	for iter := 0; iter < 200; iter++ {
+		xs := make([]int, r.Intn(64))
+		for i := range xs {
+			xs[i] = r.Intn(8)
+		}
+		h := Heapify(xs)
+		got := h.Drain()
+		for i := 1; i < len(got); i++ {
+			if got[i] > got[i-1] {
+				t.Fatalf("Heapify(%v) drained %v", xs, got)
+			}
+		}
+	}
+}
+
+func TestHeapOrderings(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	inputs := [][]int{
+		{5, 1, 5, -3, 0, 2, 2},
+		{1, 2, 3, 4, 5},
+		{5, 4, 3, 2, 1},
+	}
+	for i := 0; i < 10; i++ {
+		nums := make([]int, r.Intn(50))
+		for j := range nums {
+			nums[j] = r.Intn(20) - 10
+		}
+		inputs = append(inputs, nums)
+	}
+	for _, nums := range inputs {
+		maxH, minH := NewHeap(), NewMinHeap()
+		for _, x := range nums {
+			maxH.Push(x)
+			minH.Push(x)
+		}
+		var desc, asc []int
+		for !maxH.IsEmpty() {
+			x, _ := maxH.Pop()
+			desc = append(desc, x)
+		}
+		for !minH.IsEmpty() {
+			x, _ := minH.Pop()
+			asc = append(asc, x)
+		}
+		if len(desc) != len(nums) || len(asc) != len(nums) {
+			t.Fatalf("drained %d/%d elements, pushed %d", len(desc), len(asc), len(nums))
+		}
+		for i := range asc {
+			if i > 0 && (desc[i] > desc[i-1] || asc[i] < asc[i-1]) {
+				t.Fatalf("input %v: max drained %v, min drained %v", nums, desc, asc)
+			}
+			if asc[i] != desc[len(desc)-1-i] {
+				t.Fatalf("input %v: min drain %v is not the reverse of max drain %v", nums, asc, desc)
+			}
+		}
+	}
+}
+
+func TestMinHeapInterleaved(t *testing.T) {
+	// Dijkstra-style use: keep pushing tentative distances that are never
+	// smaller than the last one popped, and expect them back in order.
+	h := NewMinHeap()
+	h.Push(0)
+	var got []int
+	for len(got) < 12 {
+		d, ok := h.Pop()
+		if !ok {
+			t.Fatalf("min heap ran dry after %v", got)
+		}
+		got = append(got, d)
+		h.Push(d + 3)
+		h.Push(d + 1)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] < got[i-1] {
+			t.Fatalf("min heap popped %v, not ascending", got)
+		}
+	}
+
+	maxH := NewHeap()
+	maxH.Push(1)
+	maxH.Push(2)
+	if x, _ := maxH.Peek(); x != 2 {
+		t.Fatalf("NewHeap Peek = %d, want 2: no longer a max-heap", x)
+	}
+}
+
+func TestHeapGenericTypes(t *testing.T) {
+	floats := NewHeapOf[float64]()
+	for _, x := range []float64{0.25, -1.5, 3.75, 3.5, 0, -1.25} {
+		floats.Push(x)
+	}
+	for _, want := range []float64{3.75, 3.5, 0.25, 0, -1.25, -1.5} {
+		if got, ok := floats.Pop(); !ok || got != want {
+			t.Fatalf("float heap Pop = (%v, %v), want %v", got, ok, want)
+		}
+	}
+
+	words := NewMinHeapOf[string]()
+	for _, x := range []string{"pear", "apple", "Zebra", "banana", "app"} {
+		words.Push(x)
+	}
+	for _, want := range []string{"Zebra", "app", "apple", "banana", "pear"} {
+		if got, ok := words.Pop(); !ok || got != want {
+			t.Fatalf("string heap Pop = (%q, %v), want %q", got, ok, want)
+		}
+	}
+	if got, ok := words.Peek(); ok || got != "" {
+		t.Fatalf("Peek on drained string heap = (%q, %v), want zero value", got, ok)
+	}
+
+	type task struct {
+		name     string
+		priority int
+	}
+	tasks := NewHeapFunc(func(a, b task) bool { return a.priority > b.priority })
+	tasks.Push(task{"clean", 2}, task{"inverter", 9}, task{"report", 5})
+	if tasks.Size() != 3 || tasks.IsEmpty() {
+		t.Fatalf("task heap Size = %d, IsEmpty = %v", tasks.Size(), tasks.IsEmpty())
+	}
+	for _, want := range []string{"inverter", "report", "clean"} {
+		if got, ok := tasks.Pop(); !ok || got.name != want {
+			t.Fatalf("task heap Pop = (%+v, %v), want %q", got, ok, want)
+		}
+	}
+	if !tasks.IsEmpty() {
+		t.Fatalf("task heap not drained")
+	}
+
+	var ints IntHeap = NewHeapOf[int]()
+	ints.Push(-3)
+	ints.Push(2)
+	if got, _ := ints.Peek(); got != 2 {
+		t.Fatalf("IntHeap Peek = %d, want 2", got)
+	}
+}
+
+func TestNewHeapWithCapacity(t *testing.T) {
+	h := NewHeapWithCapacity(16)
+	if h.Size() != 0 || cap(h.c) != 16 {
+		t.Fatalf("NewHeapWithCapacity(16): size %d, cap %d", h.Size(), cap(h.c))
+	}
+	for i := 0; i < 16; i++ {
+		h.Push(i)
+	}
+	if cap(h.c) != 16 {
+		t.Fatalf("filling to capacity reallocated: cap %d", cap(h.c))
+	}
+	if x, _ := h.Pop(); x != 15 {
+		t.Fatalf("Pop = %d, want 15", x)
+	}
+}
+
+func TestHeapify(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for iter := 0; iter < 50; iter++ {
+		xs := make([]int, r.Intn(100))
+		for i := range xs {
+			xs[i] = r.Intn(30) - 15
+		}
+		orig := append([]int(nil), xs...)
+
+		built := Heapify(xs)
+		pushed := NewHeap()
+		for _, x := range xs {
+			pushed.Push(x)
+		}
+		if !equalInts(xs, orig) {
+			t.Fatalf("Heapify modified its input: %v, was %v", xs, orig)
+		}
+		if built.Size() != len(xs) {
+			t.Fatalf("Heapify size %d, want %d", built.Size(), len(xs))
+		}
+		for !pushed.IsEmpty() {
+			want, _ := pushed.Pop()
+			if got, ok := built.Pop(); !ok || got != want {
+				t.Fatalf("Heapify(%v) popped (%d, %v), Push-built heap popped %d", orig, got, ok, want)
+			}
+		}
+		if !built.IsEmpty() {
+			t.Fatalf("Heapify(%v) holds extra elements", orig)
+		}
+	}
+}
+
+func TestNewHeapFunc(t *testing.T) {
+	reversed := NewHeapFunc(func(a, b int) bool { return a < b })
+	for _, x := range []int{4, -2, 9, 0, 4} {
+		reversed.Push(x)
+	}
+	for _, want := range []int{-2, 0, 4, 4, 9} {
+		if got, _ := reversed.Pop(); got != want {
+			t.Fatalf("reversed int heap Pop = %d, want %d", got, want)
+		}
+	}
+
+	type reading struct {
+		watts float64
+		at    int
+	}
+	// Highest output first; among equal outputs, the earliest reading.
+	byWatts := NewHeapFunc(func(a, b reading) bool {
+		if a.watts != b.watts {
+			return a.watts > b.watts
+		}
+		return a.at < b.at
+	})
+	for _, r := range []reading{{1.5, 3}, {2.25, 7}, {1.5, 1}, {0.75, 2}, {2.25, 4}, {1.5, 2}} {
+		byWatts.Push(r)
+	}
+	want := []reading{{2.25, 4}, {2.25, 7}, {1.5, 1}, {1.5, 2}, {1.5, 3}, {0.75, 2}}
+	for _, w := range want {
+		if got, ok := byWatts.Pop(); !ok || got != w {
+			t.Fatalf("struct heap Pop = (%+v, %v), want %+v", got, ok, w)
+		}
+	}
+	if _, ok := byWatts.Pop(); ok {
+		t.Fatalf("struct heap not drained")
+	}
+}
+
+func TestNewHeapFromSlice(t *testing.T) {
+	tests := []struct {
+		name string
+		xs   []int
+	}{
+		{"nil", nil},
+		{"empty", []int{}},
+		{"single", []int{3}},
+		{"all equal", []int{2, 2, 2, 2, 2}},
+		{"ascending", []int{1, 2, 3, 4, 5, 6, 7}},
+		{"descending", []int{7, 6, 5, 4, 3, 2, 1}},
+		{"mixed", []int{0, -4, 8, 8, -1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := append([]int(nil), tt.xs...)
+			sort.Sort(sort.Reverse(sort.IntSlice(want)))
+			h := NewHeapFromSlice(append([]int(nil), tt.xs...))
+			if h.Size() != len(tt.xs) {
+				t.Fatalf("Size = %d, want %d", h.Size(), len(tt.xs))
+			}
+			for _, w := range want {
+				if got, ok := h.Pop(); !ok || got != w {
+					t.Fatalf("Pop = (%d, %v), want %d", got, ok, w)
+				}
+			}
+			if !h.IsEmpty() {
+				t.Fatalf("heap not drained")
+			}
+		})
+	}
+}
+
+func TestNewHeapFuncDefaults(t *testing.T) {
+	abs := func(x int) int {
+		if x < 0 {
+			return -x
+		}
+		return x
+	}
+	byAbs := NewHeapFunc(func(a, b int) bool { return abs(a) > abs(b) })
+	for _, x := range []int{3, -8, 0, 5, -1} {
+		byAbs.Push(x)
+	}
+	for _, want := range []int{-8, 5, 3, -1, 0} {
+		if got, _ := byAbs.Pop(); got != want {
+			t.Fatalf("abs-ordered Pop = %d, want %d", got, want)
+		}
+	}
+
+	def := NewHeapFunc[int](nil)
+	var zero Heap[string]
+	for _, x := range []int{2, 7, -3} {
+		def.Push(x)
+	}
+	for _, x := range []string{"b", "c", "a"} {
+		zero.Push(x)
+	}
+	if got, _ := def.Pop(); got != 7 {
+		t.Fatalf("nil comparator heap Pop = %d, want 7", got)
+	}
+	if got, _ := zero.Pop(); got != "c" {
+		t.Fatalf("zero Heap Pop = %q, want %q", got, "c")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("NewHeapFunc(nil) for a struct type did not panic")
+		}
+	}()
+	NewHeapFunc[struct{}](nil)
+}
+
+func TestHeapSort(t *testing.T) {
+	r := rand.New(rand.NewSource(17))
+	inputs := [][]int{nil, {}, {1}, {3, 3, 3}, {-1, -5, 0, -5, 2}}
+	for i := 0; i < 50; i++ {
+		xs := make([]int, r.Intn(100))
+		for j := range xs {
+			xs[j] = r.Intn(20) - 10
+		}
+		inputs = append(inputs, xs)
+	}
+	for _, xs := range inputs {
+		orig := append([]int(nil), xs...)
+		want := append([]int(nil), xs...)
+		sort.Ints(want)
+		if got := HeapSort(xs); !equalInts(got, want) {
+			t.Fatalf("HeapSort(%v) = %v, want %v", orig, got, want)
+		}
+		if !equalInts(xs, orig) {
+			t.Fatalf("HeapSort modified its input")
+		}
+	}
+}
+
+func TestHeapSortInPlace(t *testing.T) {
+	inputs := [][]int{nil, {}, {4}, {2, 2, 2, 2}, {1, 2, 3, 4, 5, 6}, {9, 7, 5, 3, 1}}
+	r := rand.New(rand.NewSource(29))
+	for i := 0; i < 100; i++ {
+		xs := make([]int, r.Intn(120))
+		for j := range xs {
+			xs[j] = r.Intn(30) - 15
+		}
+		inputs = append(inputs, xs)
+	}
+	for _, xs := range inputs {
+		want := append([]int(nil), xs...)
+		sort.Ints(want)
+		asc := append([]int(nil), xs...)
+		HeapSortInPlace(asc, false)
+		if !equalInts(asc, want) {
+			t.Fatalf("HeapSortInPlace(%v, false) = %v, want %v", xs, asc, want)
+		}
+		sort.Sort(sort.Reverse(sort.IntSlice(want)))
+		desc := append([]int(nil), xs...)
+		HeapSortInPlace(desc, true)
+		if !equalInts(desc, want) {
+			t.Fatalf("HeapSortInPlace(%v, true) = %v, want %v", xs, desc, want)
+		}
+	}
+	if n := testing.AllocsPerRun(10, func() { HeapSortInPlace([]int{3, 1, 2}, false) }); n != 0 {
+		t.Fatalf("HeapSortInPlace allocated %v times", n)
+	}
+}
+
+func TestHeapEmpty(t *testing.T) {
+	h := NewHeap()
+	if x, ok := h.Peek(); ok || x != 0 {
+		t.Fatalf("Peek on empty heap = (%d, %v), want (0, false)", x, ok)
+	}
+	if x, ok := h.Pop(); ok || x != 0 {
+		t.Fatalf("Pop on empty heap = (%d, %v), want (0, false)", x, ok)
+	}
+
+	h.Push(-1)
+	h.Push(-7)
+	if x, ok := h.Peek(); !ok || x != -1 {
+		t.Fatalf("Peek with -1 on top = (%d, %v), want (-1, true)", x, ok)
+	}
+	for _, want := range []int{-1, -7} {
+		if x, ok := h.Pop(); !ok || x != want {
+			t.Fatalf("Pop = (%d, %v), want (%d, true)", x, ok, want)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if x, ok := h.Pop(); ok {
+			t.Fatalf("extra Pop %d returned (%d, true) from a drained heap", i, x)
+		}
+	}
+	if !h.IsEmpty() {
+		t.Fatalf("heap not empty after over-popping")
+	}
+	h.Push(4)
+	if x, ok := h.Peek(); !ok || x != 4 {
+		t.Fatalf("Peek after reuse = (%d, %v), want (4, true)", x, ok)
+	}
+}
+
+func TestHeapSize(t *testing.T) {
+	h := NewHeap()
+	if h.Size() != 0 || h.Len() != 0 {
+		t.Fatalf("Size/Len of new heap = %d/%d, want 0", h.Size(), h.Len())
+	}
+	for i, x := range []int{4, 1, 4, -2, 9} {
+		h.Push(x)
+		if h.Size() != i+1 {
+			t.Fatalf("Size after %d pushes = %d", i+1, h.Size())
+		}
+	}
+	for n := h.Size(); h.Size() > 0; n-- {
+		h.Pop()
+		if h.Size() != n-1 {
+			t.Fatalf("Size after pop = %d, want %d", h.Size(), n-1)
+		}
+	}
+	h.Pop()
+	if h.Size() != 0 {
+		t.Fatalf("Size after popping an empty heap = %d, want 0", h.Size())
+	}
+}
+
+func TestHeapIsSilent(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	h := NewHeap()
+	for _, x := range []int{5, -1, 8, 3, 8, 0} {
+		h.Push(x)
+	}
+	for !h.IsEmpty() {
+		h.Pop()
+	}
+	h.Pop()
+	h.Peek()
+	maxSlidingWindow([]int{9, 10, 9, -7, -4, 8, 2, -6}, 5)
+
+	os.Stdout = stdout
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(out) != 0 {
+		t.Fatalf("heap operations wrote to stdout: %q", out)
+	}
+}
+
+func TestHeapPushBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	h := NewHeap()
+	h.Push(3, 1, 4, 1, 5)
+	assertHeap(t, &h)
+	var all []int
+	all = append(all, 3, 1, 4, 1, 5)
+	for iter := 0; iter < 40; iter++ {
+		// Mix small batches (sift-up path) with batches at least as large
+		// as the heap (rebuild path).
+		n := 1 + r.Intn(4)
+		if iter%5 == 0 {
+			n = h.Len() + r.Intn(8)
+		}
+		batch := make([]int, n)
+		for i := range batch {
+			batch[i] = r.Intn(100) - 50
+		}
+		h.Push(batch...)
+		all = append(all, batch...)
+		assertHeap(t, &h)
+		if h.Len() != len(all) {
+			t.Fatalf("Len = %d after pushing %d values", h.Len(), len(all))
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(all)))
+	for _, want := range all {
+		if got, _ := h.Pop(); got != want {
+			t.Fatalf("Pop = %d, want %d", got, want)
+		}
+	}
+	h.Push()
+	if !h.IsEmpty() {
+		t.Fatalf("empty Push added elements")
+	}
+}
+
+func TestHeapPushAllMixed(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	h := NewMinHeap()
+	counts := make(map[int]int)
+	for op := 0; op < 2000; op++ {
+		switch r.Intn(3) {
+		case 0:
+			x := r.Intn(50)
+			h.Push(x)
+			counts[x]++
+		case 1:
+			batch := make([]int, r.Intn(min(2*h.Len()+2, 40)))
+			for i := range batch {
+				batch[i] = r.Intn(50)
+				counts[batch[i]]++
+			}
+			h.PushAll(batch...)
+		case 2:
+			if x, ok := h.Pop(); ok {
+				if counts[x] == 0 {
+					t.Fatalf("popped %d, which is not in the heap", x)
+				}
+				counts[x]--
+			}
+		}
+		assertHeap(t, &h)
+		if h.Len() > 500 {
+			h.PopK(h.Len() - 100)
+			counts = make(map[int]int)
+			for _, x := range h.c {
+				counts[x]++
+			}
+		}
+	}
+}
+
+func TestHeapDrain(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for iter := 0; iter < 30; iter++ {
+		xs := make([]int, r.Intn(60))
+		for i := range xs {
+			xs[i] = r.Intn(40) - 20
+		}
+		want := append([]int(nil), xs...)
+		sort.Ints(want)
+
+		minH := NewMinHeap()
+		minH.Push(xs...)
+		if got := minH.Drain(); !equalInts(got, want) {
+			t.Fatalf("min Drain = %v, want %v", got, want)
+		}
+		maxH := Heapify(xs)
+		got := maxH.Drain()
+		for i := range got {
+			if got[i] != want[len(want)-1-i] {
+				t.Fatalf("max Drain = %v, want reverse of %v", got, want)
+			}
+		}
+		if !maxH.IsEmpty() || !minH.IsEmpty() {
+			t.Fatalf("Drain left elements behind")
+		}
+	}
+
+	h := NewHeap()
+	if got := h.Drain(); got == nil || len(got) != 0 {
+		t.Fatalf("Drain of empty heap = %#v, want empty non-nil slice", got)
+	}
+	h.Push(2, 9)
+	if got := h.Drain(); !equalInts(got, []int{9, 2}) {
+		t.Fatalf("Drain after reuse = %v", got)
+	}
+}
+
+func TestHeapPushPop(t *testing.T) {
+	r := rand.New(rand.NewSource(14))
+	fast, naive := NewHeap(), NewHeap()
+	if got := fast.PushPop(3); got != 3 || !fast.IsEmpty() {
+		t.Fatalf("PushPop on empty heap = %d, len %d", got, fast.Len())
+	}
+	for i := 0; i < 20; i++ {
+		x := r.Intn(30)
+		fast.Push(x)
+		naive.Push(x)
+	}
+	for i := 0; i < 300; i++ {
+		x := r.Intn(40) - 5
+		got := fast.PushPop(x)
+		naive.Push(x)
+		want, _ := naive.Pop()
+		if got != want {
+			t.Fatalf("PushPop(%d) = %d, Push+Pop gave %d", x, got, want)
+		}
+		assertHeap(t, &fast)
+		if f, n := fast.Sorted(), naive.Sorted(); !equalInts(f, n) {
+			t.Fatalf("PushPop(%d) left %v, Push+Pop left %v", x, f, n)
+		}
+	}
+
+	h := Heapify([]int{5, 2})
+	before := append([]int(nil), h.c...)
+	if got := h.PushPop(5); got != 5 || !equalInts(h.c, before) {
+		t.Fatalf("PushPop of a value equal to the top = %d, heap %v, want it untouched", got, h.c)
+	}
+}
+
+func TestHeapReplace(t *testing.T) {
+	h := NewHeap()
+	if x, ok := h.Replace(4); ok || x != 0 {
+		t.Fatalf("Replace on empty heap = (%d, %v), want (0, false)", x, ok)
+	}
+	if x, _ := h.Peek(); h.Len() != 1 || x != 4 {
+		t.Fatalf("Replace on empty heap did not push: len %d, top %d", h.Len(), x)
+	}
+	h.Push(9, 1, 7, 3)
+	for _, tc := range []struct{ x, old int }{{2, 9}, {10, 7}, {0, 10}, {5, 4}} {
+		if old, ok := h.Replace(tc.x); !ok || old != tc.old {
+			t.Fatalf("Replace(%d) = (%d, %v), want (%d, true)", tc.x, old, ok, tc.old)
+		}
+		assertHeap(t, &h)
+		if h.Len() != 5 {
+			t.Fatalf("Replace changed Len to %d", h.Len())
+		}
+	}
+	if got := h.Drain(); !equalInts(got, []int{5, 3, 2, 1, 0}) {
+		t.Fatalf("Drain after Replace = %v", got)
+	}
+
+	r := rand.New(rand.NewSource(31))
+	fast, naive := NewHeap(), NewHeap()
+	for i := 0; i < 20; i++ {
+		x := r.Intn(30)
+		fast.Push(x)
+		naive.Push(x)
+	}
+	for i := 0; i < 300; i++ {
+		x := r.Intn(40) - 5
+		got, _ := fast.Replace(x)
+		want, _ := naive.Pop()
+		naive.Push(x)
+		if got != want {
+			t.Fatalf("Replace(%d) = %d, Pop+Push gave %d", x, got, want)
+		}
+		assertHeap(t, &fast)
+		if f, n := fast.Sorted(), naive.Sorted(); !equalInts(f, n) {
+			t.Fatalf("Replace(%d) left %v, Pop+Push left %v", x, f, n)
+		}
+	}
+}
+
+func TestHeapIterators(t *testing.T) {
+	r := rand.New(rand.NewSource(37))
+	xs := make([]int, 60)
+	for i := range xs {
+		xs[i] = r.Intn(20)
+	}
+	h := NewHeap()
+	h.Push(xs...)
+
+	var all []int
+	for x := range h.All() {
+		all = append(all, x)
+	}
+	sort.Ints(all)
+	want := append([]int(nil), xs...)
+	sort.Ints(want)
+	if !equalInts(all, want) {
+		t.Fatalf("All yielded %v, want the multiset %v", all, want)
+	}
+
+	before := h.String()
+	var top []int
+	for x := range h.Descend() {
+		if len(top) == 5 {
+			break
+		}
+		top = append(top, x)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(want)))
+	if !equalInts(top, want[:5]) {
+		t.Fatalf("first five from Descend = %v, want %v", top, want[:5])
+	}
+	if h.String() != before || h.Len() != len(xs) {
+		t.Fatalf("breaking out of Descend changed the heap")
+	}
+	assertHeap(t, &h)
+	var desc []int
+	for x := range h.Descend() {
+		desc = append(desc, x)
+	}
+	if !equalInts(desc, want) {
+		t.Fatalf("Descend = %v, want %v", desc, want)
+	}
+	for range h.All() {
+		break
+	}
+
+	empty := NewMinHeap()
+	for x := range empty.Descend() {
+		t.Fatalf("Descend on an empty heap yielded %d", x)
+	}
+}
+
+func TestHeapValues(t *testing.T) {
+	empty := NewHeap()
+	if got := empty.Values(); got == nil || len(got) != 0 {
+		t.Fatalf("Values of an empty heap = %#v, want empty", got)
+	}
+	h := Heapify([]int{3, 8, 1, 6, 6})
+	vs := h.Values()
+	if top, _ := h.Peek(); len(vs) != 5 || vs[0] != top {
+		t.Fatalf("Values = %v, want 5 elements starting with %d", vs, top)
+	}
+	got := append([]int(nil), vs...)
+	sort.Ints(got)
+	if !equalInts(got, []int{1, 3, 6, 6, 8}) {
+		t.Fatalf("Values holds %v", got)
+	}
+	for i := range vs {
+		vs[i] = -100
+	}
+	if top, _ := h.Peek(); top != 8 {
+		t.Fatalf("Peek after mutating Values = %d, want 8", top)
+	}
+	assertHeap(t, &h)
+	if got := h.Drain(); !equalInts(got, []int{8, 6, 6, 3, 1}) {
+		t.Fatalf("Drain after mutating Values = %v", got)
+	}
+}
+
+func TestHeapSortedEach(t *testing.T) {
+	h := Heapify([]int{4, 8, -1, 8, 2})
+	before := h.String()
+	if got := h.Sorted(); !equalInts(got, []int{8, 8, 4, 2, -1}) {
+		t.Fatalf("Sorted = %v", got)
+	}
+	var seen []int
+	h.Each(func(x int) bool {
+		seen = append(seen, x)
+		return x > 4
+	})
+	if !equalInts(seen, []int{8, 8, 4}) {
+		t.Fatalf("Each visited %v, want it to stop after 4", seen)
+	}
+	if h.Size() != 5 || h.String() != before {
+		t.Fatalf("iteration changed the heap: size %d, %v, was %s", h.Size(), h, before)
+	}
+}
+
+func TestHeapRemoveAt(t *testing.T) {
+	base := []int{20, 15, 8, 4, 14, 7, 6, 1, 2, 13}
+	tests := []struct {
+		name string
+		i    int
+	}{
+		{"root", 0},
+		{"middle", 3},
+		{"sift up", 6}, // the last element, 13, lands under 8 and must rise
+		{"last", len(base) - 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeapFromSlice(append([]int(nil), base...))
+			if !equalInts(h.c, base) {
+				t.Fatalf("base is not in heap order: %v", h.c)
+			}
+			want := h.c[tt.i]
+			got, ok := h.RemoveAt(tt.i)
+			if !ok || got != want {
+				t.Fatalf("RemoveAt(%d) = (%d, %v), want (%d, true)", tt.i, got, ok, want)
+			}
+			assertHeap(t, &h)
+			if h.Len() != len(base)-1 {
+				t.Fatalf("Len = %d after RemoveAt", h.Len())
+			}
+		})
+	}
+	h := Heapify(base)
+	for _, i := range []int{-1, len(base), 100} {
+		if _, ok := h.RemoveAt(i); ok || h.Len() != len(base) {
+			t.Fatalf("RemoveAt(%d) out of range reported ok", i)
+		}
+	}
+
+	r := rand.New(rand.NewSource(22))
+	h = Heapify(r.Perm(200))
+	for !h.IsEmpty() {
+		h.RemoveAt(r.Intn(h.Len()))
+		assertHeap(t, &h)
+	}
+}
+
+func TestHeapRemoveValue(t *testing.T) {
+	base := []int{20, 15, 8, 4, 14, 7, 6, 1, 2, 13}
+	for _, tt := range []struct {
+		name string
+		x    int
+	}{
+		{"root", 20},
+		{"leaf", 2},
+		{"internal, sift up", 6}, // 13 replaces 6 under 8 and must rise
+		{"internal, sift down", 15},
+		{"last", 13},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHeapFromSlice(append([]int(nil), base...))
+			if !RemoveValue(&h, tt.x) {
+				t.Fatalf("RemoveValue(%d) reported false", tt.x)
+			}
+			assertHeap(t, &h)
+			want := make([]int, 0, len(base)-1)
+			for _, v := range base {
+				if v != tt.x {
+					want = append(want, v)
+				}
+			}
+			sort.Sort(sort.Reverse(sort.IntSlice(want)))
+			if got := h.Drain(); !equalInts(got, want) {
+				t.Fatalf("after RemoveValue(%d) drained %v, want %v", tt.x, got, want)
+			}
+		})
+	}
+
+	h := Heapify([]int{5, 3, 5, 1, 5})
+	if RemoveValue(&h, 4) || h.Len() != 5 {
+		t.Fatalf("RemoveValue of an absent value changed the heap")
+	}
+	for n := 2; n >= 0; n-- {
+		if !RemoveValue(&h, 5) {
+			t.Fatalf("RemoveValue(5) failed with %d copies left", n+1)
+		}
+		assertHeap(t, &h)
+		if got := h.Len(); got != n+2 {
+			t.Fatalf("Len = %d, want %d", got, n+2)
+		}
+	}
+	if RemoveValue(&h, 5) {
+		t.Fatalf("RemoveValue(5) succeeded with no copies left")
+	}
+	if got := h.Drain(); !equalInts(got, []int{3, 1}) {
+		t.Fatalf("Drain = %v, want [3 1]", got)
+	}
+
+	type job struct{ id, prio int }
+	jobs := NewHeapFunc(func(a, b job) bool { return a.prio > b.prio })
+	jobs.Push(job{1, 3}, job{2, 9}, job{3, 3})
+	if !jobs.RemoveFunc(func(j job) bool { return j.id == 3 }) || jobs.RemoveFunc(func(j job) bool { return j.id == 3 }) {
+		t.Fatalf("RemoveFunc by id misreported")
+	}
+	if got := jobs.Drain(); len(got) != 2 || got[0].id != 2 || got[1].id != 1 {
+		t.Fatalf("jobs after RemoveFunc = %v", got)
+	}
+}
+
+func TestHeapContains(t *testing.T) {
+	empty := NewHeap()
+	if ContainsValue(&empty, 0) {
+		t.Fatalf("empty heap contains 0")
+	}
+	h := Heapify([]int{7, -3, 12, 0, 7})
+	for _, x := range []int{7, -3, 12, 0} {
+		if !ContainsValue(&h, x) {
+			t.Errorf("ContainsValue(%d) = false", x)
+		}
+	}
+	for _, x := range []int{1, -7, 13} {
+		if ContainsValue(&h, x) {
+			t.Errorf("ContainsValue(%d) = true", x)
+		}
+	}
+	if h.Len() != 5 || !h.ContainsFunc(func(x int) bool { return x < 0 }) || h.ContainsFunc(func(x int) bool { return x > 12 }) {
+		t.Fatalf("ContainsFunc misreported")
+	}
+}
+
+func TestHeapMovedHook(t *testing.T) {
+	for _, opts := range [][]Option{nil, {WithStable()}, {WithArity(3)}} {
+		h := NewHeap(opts...)
+		pos := make(map[int]int)
+		h.moved = func(x, i int) {
+			if i < 0 {
+				if _, ok := pos[x]; !ok {
+					t.Fatalf("moved(%d, -1) for a value not in the heap", x)
+				}
+				delete(pos, x)
+			} else {
+				pos[x] = i
+			}
+		}
+		check := func(op string) {
+			t.Helper()
+			if len(pos) != h.Len() {
+				t.Fatalf("after %s: %d positions for %d elements", op, len(pos), h.Len())
+			}
+			for i, x := range h.c {
+				if pos[x] != i {
+					t.Fatalf("after %s: pos[%d] = %d, element is at %d", op, x, pos[x], i)
+				}
+			}
+		}
+		// Values are distinct so each one names a single slot.
+		next := 0
+		fresh := func(n int) []int {
+			xs := make([]int, n)
+			for i := range xs {
+				xs[i] = next * 7 % 1000
+				next++
+			}
+			return xs
+		}
+		h.Push(fresh(1)...)
+		check("Push")
+		h.Push(fresh(20)...)
+		check("Push of a batch")
+		h.Pop()
+		check("Pop")
+		h.PushPop(fresh(1)[0])
+		check("PushPop")
+		h.Replace(fresh(1)[0])
+		check("Replace")
+		RemoveValue(&h, h.c[h.Len()/2])
+		check("RemoveValue")
+		h.retain(func(x int) bool { return x%3 != 0 })
+		check("retain")
+		other := Heapify(fresh(15))
+		h.Merge(&other)
+		check("Merge")
+		data, _ := json.Marshal(Heapify(fresh(10)))
+		if err := json.Unmarshal(data, &h); err != nil {
+			t.Fatal(err)
+		}
+		check("UnmarshalJSON")
+		src := Heapify(fresh(10))
+		data, _ = src.GobEncode()
+		if err := h.GobDecode(data); err != nil {
+			t.Fatal(err)
+		}
+		check("GobDecode")
+		h.Compact()
+		check("Compact")
+		h.Reset(4)
+		check("Reset")
+		h.Push(fresh(5)...)
+		h.Clear()
+		check("Clear")
+		h.Push(fresh(5)...)
+		h.ClearAndRelease()
+		check("ClearAndRelease")
+	}
+}
+
+func TestHeapEqual(t *testing.T) {
+	pushed := NewHeap()
+	pushed.Push(3, 1, 4, 1, 5, 9, 2, 6)
+	built := Heapify([]int{9, 6, 5, 4, 3, 2, 1, 1})
+	if equalInts(pushed.Values(), built.Values()) {
+		t.Fatalf("test heaps share a backing order %v", built.Values())
+	}
+	before := pushed.String()
+	if !pushed.Equal(&built) || !built.Equal(&pushed) {
+		t.Fatalf("%v and %v hold the same elements but are not Equal", pushed.Values(), built.Values())
+	}
+	if pushed.String() != before || pushed.Len() != 8 {
+		t.Fatalf("Equal modified the heap")
+	}
+
+	var a, b IntHeap
+	if !a.Equal(&b) {
+		t.Fatalf("zero heaps are not Equal")
+	}
+	// Same length and top, different counts of 1 and 2.
+	a.Push(5, 1, 1)
+	b.Push(5, 2, 1)
+	if a.Equal(&b) {
+		t.Fatalf("%v Equal %v", a.Values(), b.Values())
+	}
+	b = Heapify([]int{5, 1})
+	if a.Equal(&b) || b.Equal(&a) {
+		t.Fatalf("heaps of different sizes are Equal")
+	}
+
+	type task struct {
+		name     string
+		priority int
+	}
+	byPriority := func(x, y task) bool { return x.priority > y.priority }
+	t1, t2 := NewHeapFunc(byPriority), NewHeapFunc(byPriority)
+	t1.Push(task{"a", 1}, task{"b", 2})
+	t2.Push(task{"c", 2}, task{"d", 1})
+	if !t1.Equal(&t2) {
+		t.Fatalf("tasks tying on priority are not Equal")
+	}
+}
+
+func TestHeapPeekN(t *testing.T) {
+	r := rand.New(rand.NewSource(24))
+	for iter := 0; iter < 50; iter++ {
+		xs := make([]int, r.Intn(80))
+		for i := range xs {
+			xs[i] = r.Intn(30)
+		}
+		h := Heapify(xs)
+		before := h.String()
+		want := h.Sorted()
+		k := r.Intn(len(xs) + 3)
+		if got := h.PeekN(k); !equalInts(got, want[:min(k, len(want))]) {
+			t.Fatalf("PeekN(%d) of %v = %v, want %v", k, xs, got, want[:min(k, len(want))])
+		}
+		if h.String() != before {
+			t.Fatalf("PeekN modified the heap")
+		}
+	}
+	h := Heapify([]int{1, 2})
+	for _, k := range []int{0, -3} {
+		if got := h.PeekN(k); got == nil || len(got) != 0 {
+			t.Fatalf("PeekN(%d) = %#v, want empty", k, got)
+		}
+	}
+}
+
+func TestHeapPeekK(t *testing.T) {
+	h := Heapify([]int{6, 2, 9, 2, 5})
+	before := h.String()
+	tests := []struct {
+		k    int
+		want []int
+		ok   bool
+	}{
+		{0, []int{}, true},
+		{2, []int{9, 6}, true},
+		{h.Size(), []int{9, 6, 5, 2, 2}, true},
+		{h.Size() + 1, []int{9, 6, 5, 2, 2}, false},
+		{-1, []int{}, false},
+	}
+	for _, tt := range tests {
+		got, ok := h.PeekK(tt.k)
+		if ok != tt.ok || got == nil || !equalInts(got, tt.want) {
+			t.Errorf("PeekK(%d) = (%v, %v), want (%v, %v)", tt.k, got, ok, tt.want, tt.ok)
+		}
+	}
+	if h.String() != before {
+		t.Fatalf("PeekK modified the heap")
+	}
+}
+
+func TestHeapKth(t *testing.T) {
+	r := rand.New(rand.NewSource(27))
+	for iter := 0; iter < 50; iter++ {
+		xs := make([]int, 1+r.Intn(80))
+		for i := range xs {
+			xs[i] = r.Intn(20) - 10
+		}
+		h := Heapify(xs)
+		before := h.String()
+		want := append([]int(nil), xs...)
+		sort.Sort(sort.Reverse(sort.IntSlice(want)))
+		for k := 1; k <= len(xs); k++ {
+			if got, ok := h.Kth(k); !ok || got != want[k-1] {
+				t.Fatalf("Kth(%d) of %v = (%d, %v), want %d", k, xs, got, ok, want[k-1])
+			}
+		}
+		if top, _ := h.Peek(); top != want[0] {
+			t.Fatalf("Peek = %d, want %d", top, want[0])
+		}
+		if h.String() != before {
+			t.Fatalf("Kth modified the heap")
+		}
+	}
+	h := Heapify([]int{5, 5, 5})
+	if got, ok := h.Kth(3); !ok || got != 5 {
+		t.Fatalf("Kth(3) with duplicates = (%d, %v)", got, ok)
+	}
+	for _, k := range []int{0, -1, 4} {
+		if _, ok := h.Kth(k); ok {
+			t.Fatalf("Kth(%d) reported ok for a heap of 3", k)
+		}
+	}
+	empty := NewHeap()
+	if _, ok := empty.Kth(1); ok {
+		t.Fatalf("Kth(1) on an empty heap reported ok")
+	}
+}
+
+func TestHeapPopK(t *testing.T) {
+	h := Heapify([]int{4, 9, -2, 7, 7, 0})
+	if got := h.PopK(3); !equalInts(got, []int{9, 7, 7}) {
+		t.Fatalf("PopK(3) = %v", got)
+	}
+	assertHeap(t, &h)
+	if h.Len() != 3 {
+		t.Fatalf("Len after PopK(3) = %d, want 3", h.Len())
+	}
+	for _, k := range []int{0, -1} {
+		if got := h.PopK(k); got == nil || len(got) != 0 || h.Len() != 3 {
+			t.Fatalf("PopK(%d) = %#v, Len %d", k, got, h.Len())
+		}
+	}
+	if got := h.PopK(10); !equalInts(got, []int{4, 0, -2}) {
+		t.Fatalf("PopK past the end = %v", got)
+	}
+	if !h.IsEmpty() {
+		t.Fatalf("heap not empty after oversized PopK")
+	}
+}
+
+func TestHeapClone(t *testing.T) {
+	h := NewMinHeap()
+	h.Push(5, 3, 8, 3, 1)
+	c := h.Clone()
+	if got := c.Drain(); !equalInts(got, []int{1, 3, 3, 5, 8}) {
+		t.Fatalf("clone Drain = %v", got)
+	}
+	if x, _ := h.Peek(); x != 1 || h.Len() != 5 {
+		t.Fatalf("original after draining clone: top %d, len %d", x, h.Len())
+	}
+	c.Push(-10)
+	h.Push(0)
+	if x, _ := h.Peek(); x != 0 {
+		t.Fatalf("original Peek = %d after pushing to clone", x)
+	}
+	if x, _ := c.Peek(); x != -10 || c.Len() != 1 {
+		t.Fatalf("clone Peek = %d, len %d", x, c.Len())
+	}
+}
+
+func TestHeapCloneIsolation(t *testing.T) {
+	h := NewHeapFunc(func(a, b string) bool { return len(a) > len(b) })
+	h.Push("a", "ccc", "bb")
+	traced := 0
+	h.SetTracer(func(string, []string) { traced++ })
+
+	c := h.Clone()
+	if got := c.PopK(2); got[0] != "ccc" || got[1] != "bb" {
+		t.Fatalf("clone lost the comparator: popped %q", got)
+	}
+	if traced != 0 {
+		t.Fatalf("popping the clone fired the original's tracer %d times", traced)
+	}
+	if x, _ := h.Peek(); x != "ccc" || h.Size() != 3 {
+		t.Fatalf("original after popping clone: top %q, size %d", x, h.Size())
+	}
+}
+
+func TestHeapClearReset(t *testing.T) {
+	h := NewHeapWithCapacity(8)
+	h.Push(1, 2, 3, 4, 5)
+	base := &h.c[0]
+	h.Clear()
+	if !h.IsEmpty() || cap(h.c) != 8 {
+		t.Fatalf("after Clear: len %d, cap %d", h.Len(), cap(h.c))
+	}
+	for i := 0; i < 8; i++ {
+		h.Push(i)
+	}
+	if &h.c[0] != base {
+		t.Fatalf("pushing up to capacity after Clear reallocated")
+	}
+	h.Push(8)
+	if cap(h.c) <= 8 {
+		t.Fatalf("cap %d after exceeding capacity", cap(h.c))
+	}
+
+	h.ClearAndRelease()
+	if !h.IsEmpty() || cap(h.c) != 0 {
+		t.Fatalf("after ClearAndRelease: len %d, cap %d", h.Len(), cap(h.c))
+	}
+	h.Push(4)
+	if x, _ := h.Peek(); x != 4 {
+		t.Fatalf("Peek after ClearAndRelease and Push = %d", x)
+	}
+
+	h.Reset(2)
+	if !h.IsEmpty() || cap(h.c) != 2 {
+		t.Fatalf("after Reset(2): len %d, cap %d", h.Len(), cap(h.c))
+	}
+	h.Push(3, 7)
+	if x, _ := h.Pop(); x != 7 {
+		t.Fatalf("Pop after Reset = %d, want 7", x)
+	}
+}
+
+func TestHeapCompact(t *testing.T) {
+	h := NewHeap()
+	for i := 0; i < 100000; i++ {
+		h.Push(i)
+	}
+	h.PopK(99990)
+	if cap(h.c) < 100000 {
+		t.Fatalf("cap %d after draining, expected the burst capacity to remain", cap(h.c))
+	}
+	h.Compact()
+	if cap(h.c) != 10 {
+		t.Fatalf("cap %d after Compact, want 10", cap(h.c))
+	}
+	assertHeap(t, &h)
+	if got := h.Drain(); !equalInts(got, []int{9, 8, 7, 6, 5, 4, 3, 2, 1, 0}) {
+		t.Fatalf("Drain after Compact = %v", got)
+	}
+
+	h.Reset(8)
+	h.Push(1, 2, 3, 4)
+	h.Compact()
+	if cap(h.c) != 8 {
+		t.Fatalf("Compact shrank a half-full heap to cap %d", cap(h.c))
+	}
+}
+
+func TestHeapMerge(t *testing.T) {
+	a, b := NewHeap(), NewHeap()
+	a.Push(5, 1, 9)
+	b.Push(12, -3, 9, 0)
+	a.Merge(&b)
+	if !b.IsEmpty() {
+		t.Fatalf("merged-from heap still holds %d elements", b.Len())
+	}
+	assertHeap(t, &a)
+	if got, want := a.Drain(), []int{12, 9, 9, 5, 1, 0, -3}; !equalInts(got, want) {
+		t.Fatalf("merged Drain = %v, want %v", got, want)
+	}
+
+	a.Push(4, 2)
+	empty := NewHeap()
+	a.Merge(&empty)
+	empty.Merge(&a)
+	if a.Len() != 0 || empty.Len() != 2 {
+		t.Fatalf("merge with empty: sizes %d and %d, want 0 and 2", a.Len(), empty.Len())
+	}
+	empty.Merge(&empty)
+	if got := empty.Drain(); !equalInts(got, []int{4, 2}) {
+		t.Fatalf("self-merge changed the heap: %v", got)
+	}
+}
+
+func TestHeapMergeDisjointRanges(t *testing.T) {
+	evens, odds := NewHeap(), NewHeap()
+	for i := 0; i < 50; i++ {
+		evens.Push(2 * i)
+	}
+	for i := 0; i < 30; i++ {
+		odds.Push(2*i + 1)
+	}
+	low, high := NewHeap(), NewHeap()
+	low.Push(-5, -4, -3)
+	high.Push(1000, 1001)
+
+	evens.Merge(&odds)
+	evens.Merge(&low)
+	evens.Merge(&high)
+	want := []int{1001, 1000}
+	for x := 98; x >= 0; x-- {
+		if x%2 == 0 || x < 60 {
+			want = append(want, x)
+		}
+	}
+	want = append(want, -3, -4, -5)
+	if got := evens.Drain(); !equalInts(got, want) {
+		t.Fatalf("merged Drain = %v, want %v", got, want)
+	}
+}
+
+func TestHeapCheckInvariant(t *testing.T) {
+	r := rand.New(rand.NewSource(19))
+	maxH, minH := NewHeap(), NewMinHeap()
+	for op := 0; op < 5000; op++ {
+		for _, h := range []*IntHeap{&maxH, &minH} {
+			if r.Intn(3) == 0 {
+				h.Pop()
+			} else {
+				h.Push(r.Intn(200) - 100)
+			}
+			if err := h.CheckInvariant(); err != nil {
+				t.Fatalf("after op %d: %v", op, err)
+			}
+		}
+	}
+
+	bad := Heapify([]int{9, 5, 8, 1, 2})
+	bad.c[4] = 6
+	err := bad.CheckInvariant()
+	if err == nil || !strings.Contains(err.Error(), "index 4") {
+		t.Fatalf("CheckInvariant on corrupted heap = %v, want a violation at index 4", err)
+	}
+}
+
+func TestHeapIsValid(t *testing.T) {
+	maxH := Heapify([]int{3, 1, 4, 1, 5, 9, 2, 6})
+	minH := NewMinHeap()
+	minH.Push(3, 1, 4, 1, 5, 9, 2, 6)
+	var empty IntHeap
+	for _, h := range []*IntHeap{&maxH, &minH, &empty} {
+		if !h.IsValid() {
+			t.Fatalf("IsValid = false for %v", h)
+		}
+	}
+
+	maxH.c[len(maxH.c)-1] = 100
+	minH.c[0] = 100
+	for _, h := range []*IntHeap{&maxH, &minH} {
+		if h.IsValid() {
+			t.Fatalf("IsValid = true for corrupted %v", h)
+		}
+	}
+}
+
+func TestHeapString(t *testing.T) {
+	tests := []struct {
+		h    IntHeap
+		want string
+	}{
+		{NewHeap(), "[]"},
+		{Heapify([]int{4}), "[4]"},
+		{Heapify([]int{1, 2, 3, 5, 7, 9}), "[9 | 7 3 | 5 2 1]"},
+	}
+	for _, tt := range tests {
+		if got := tt.h.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+
+	big := Heapify(rand.New(rand.NewSource(18)).Perm(1000))
+	got := fmt.Sprint(big)
+	if !strings.HasSuffix(got, "| ... 937 more]") || strings.Count(got, "|") != maxStringLevels {
+		t.Fatalf("large heap rendered as %q", got)
+	}
+}
+
+func TestHeapStable(t *testing.T) {
+	type reading struct{ level, id int }
+	byLevel := func(a, b reading) bool { return a.level > b.level }
+	r := rand.New(rand.NewSource(27))
+
+	h := NewHeapFunc(byLevel, WithStable())
+	for id := 0; id < 300; id++ {
+		x := reading{level: 5, id: id}
+		if id%3 != 0 {
+			x.level = r.Intn(10)
+		}
+		h.Push(x)
+		if id%50 == 49 {
+			// Interleave pops; what remains must still come out FIFO.
+			h.PopK(10)
+		}
+	}
+	// A batch at least as large as the heap takes the rebuild path.
+	var batch []reading
+	for id := 300; id < 600; id++ {
+		batch = append(batch, reading{level: 5, id: id})
+	}
+	h.PushAll(batch...)
+	lastID := map[int]int{}
+	got := h.Drain()
+	for i, x := range got {
+		if i > 0 && x.level > got[i-1].level {
+			t.Fatalf("popped level %d after %d", x.level, got[i-1].level)
+		}
+		if prev, ok := lastID[x.level]; ok && x.id < prev {
+			t.Fatalf("level %d: id %d popped after id %d", x.level, x.id, prev)
+		}
+		lastID[x.level] = x.id
+	}
+
+	// The order survives PushPop, Replace, Merge and Clone.
+	a := NewHeapFunc(byLevel, WithStable())
+	b := NewHeapFunc(byLevel, WithStable())
+	a.Push(reading{1, 0}, reading{1, 1})
+	b.Push(reading{1, 2}, reading{1, 3})
+	if x := a.PushPop(reading{1, 9}); x.id != 0 {
+		t.Fatalf("PushPop on a tie returned id %d, want the older 0", x.id)
+	}
+	if x, _ := a.Replace(reading{1, 4}); x.id != 1 {
+		t.Fatalf("Replace returned id %d, want 1", x.id)
+	}
+	a.Merge(&b)
+	c := a.Clone()
+	var ids []int
+	for _, x := range c.Drain() {
+		ids = append(ids, x.id)
+	}
+	if want := []int{9, 4, 2, 3}; !equalInts(ids, want) {
+		t.Fatalf("stable merge/clone popped ids %v, want %v", ids, want)
+	}
+
+	plain := NewHeap()
+	if plain.stable || plain.seq != nil {
+		t.Fatalf("NewHeap() without options is stable")
+	}
+}
+
+func TestHeapOptions(t *testing.T) {
+	h := NewHeap(WithCapacity(100))
+	if cap(h.c) != 100 {
+		t.Fatalf("WithCapacity(100) gave cap %d", cap(h.c))
+	}
+	for x := 0; x < 100; x++ {
+		h.Push(x)
+	}
+	if cap(h.c) != 100 {
+		t.Fatalf("filling a WithCapacity(100) heap grew it to cap %d", cap(h.c))
+	}
+	if top, _ := h.Peek(); top != 99 {
+		t.Fatalf("NewHeap(WithCapacity) top = %d, want 99", top)
+	}
+
+	m := NewHeap(WithMinOrdering(), WithCapacity(8), WithArity(3))
+	m.Push(5, -2, 9, 0, 5)
+	if got := m.Drain(); !equalInts(got, []int{-2, 0, 5, 5, 9}) {
+		t.Fatalf("WithMinOrdering Drain = %v", got)
+	}
+	s := NewHeapOf[string](WithMinOrdering())
+	s.Push("pear", "apple", "fig")
+	if got, _ := s.Pop(); got != "apple" {
+		t.Fatalf("NewHeapOf[string](WithMinOrdering()) popped %q", got)
+	}
+	f := NewHeapFunc(greater[int], WithMinOrdering())
+	f.Push(1, 3, 2)
+	if got, _ := f.Pop(); got != 3 {
+		t.Fatalf("WithMinOrdering changed NewHeapFunc's order: popped %d", got)
+	}
+	if d := NewHeap(); cap(d.c) != 1 || d.stable || d.arity != 0 {
+		t.Fatalf("NewHeap() without options changed: cap %d, stable %v, arity %d", cap(d.c), d.stable, d.arity)
+	}
+}
+
+func TestHeapArity(t *testing.T) {
+	for _, d := range []int{2, 3, 4, 8} {
+		t.Run(fmt.Sprint("d=", d), func(t *testing.T) {
+			r := rand.New(rand.NewSource(int64(d)))
+			h := NewHeap(WithArity(d))
+			var ref []int
+			for op := 0; op < 2000; op++ {
+				switch r.Intn(5) {
+				case 0, 1:
+					x := r.Intn(100)
+					h.Push(x)
+					ref = append(ref, x)
+				case 2:
+					if got, ok := h.Pop(); ok {
+						sort.Ints(ref)
+						if want := ref[len(ref)-1]; got != want {
+							t.Fatalf("Pop = %d, want %d", got, want)
+						}
+						ref = ref[:len(ref)-1]
+					}
+				case 3:
+					if h.Len() > 0 {
+						x, _ := h.RemoveAt(r.Intn(h.Len()))
+						sort.Ints(ref)
+						i := sort.SearchInts(ref, x)
+						ref = append(ref[:i], ref[i+1:]...)
+					}
+				case 4:
+					batch := make([]int, r.Intn(min(2*h.Len()+2, 40)))
+					for i := range batch {
+						batch[i] = r.Intn(100)
+					}
+					h.Push(batch...)
+					ref = append(ref, batch...)
+				}
+				assertHeap(t, &h)
+			}
+			want := append([]int(nil), ref...)
+			sort.Sort(sort.Reverse(sort.IntSlice(want)))
+			if got := h.PeekN(10); !equalInts(got, want[:min(10, len(want))]) {
+				t.Fatalf("PeekN(10) = %v, want %v", got, want[:min(10, len(want))])
+			}
+			c := h.Clone()
+			if c.arity != h.arity {
+				t.Fatalf("Clone dropped the arity")
+			}
+			if got := h.Drain(); !equalInts(got, want) {
+				t.Fatalf("Drain = %v, want %v", got, want)
+			}
+		})
+	}
+
+	h := NewHeap(WithArity(3))
+	h.Push(1, 2, 3, 4, 5, 6, 7)
+	if got, want := h.String(), "[7 | 6 3 4 | 5 1 2]"; got != want {
+		t.Fatalf("ternary String = %q, want %q", got, want)
+	}
+	if low := NewHeap(WithArity(1)); low.d() != 2 {
+		t.Fatalf("WithArity(1) gave arity %d, want 2", low.d())
+	}
+}
+
+func TestHeapStats(t *testing.T) {
+	h := NewHeap(WithStats())
+	h.Push(3) // nothing to compare against
+	h.Push(5) // 5 > 3: one comparison, one swap
+	h.Push(1) // 1 > 5 fails: one comparison
+	if got, want := h.Stats(), (HeapStats{Comparisons: 2, Swaps: 1, Pushes: 3}); got != want {
+		t.Fatalf("after pushes Stats = %+v, want %+v", got, want)
+	}
+	// Pop swaps 5 with the last slot, then sifts 1 below 3: one comparison
+	// and one more swap.
+	if x, _ := h.Pop(); x != 5 {
+		t.Fatalf("Pop = %d, want 5", x)
+	}
+	if got, want := h.Stats(), (HeapStats{Comparisons: 3, Swaps: 3, Pushes: 3, Pops: 1}); got != want {
+		t.Fatalf("after Pop Stats = %+v, want %+v", got, want)
+	}
+	// PushPop compares 0 with the root, then sifts it below 1.
+	if x := h.PushPop(0); x != 3 {
+		t.Fatalf("PushPop(0) = %d, want 3", x)
+	}
+	if got, want := h.Stats(), (HeapStats{Comparisons: 5, Swaps: 4, Pushes: 4, Pops: 2}); got != want {
+		t.Fatalf("after PushPop Stats = %+v, want %+v", got, want)
+	}
+	if c := h.Clone(); c.Stats() != (HeapStats{}) || !c.counting {
+		t.Fatalf("Clone should keep counting from zero, got %+v", c.Stats())
+	}
+	h.ResetStats()
+	if got := h.Stats(); got != (HeapStats{}) {
+		t.Fatalf("Stats after ResetStats = %+v", got)
+	}
+
+	plain := NewHeap()
+	plain.Push(4, 2, 9)
+	plain.Pop()
+	if got := plain.Stats(); got != (HeapStats{}) {
+		t.Fatalf("heap without WithStats counted %+v", got)
+	}
+}
+
+func TestHeapTracer(t *testing.T) {
+	h := NewHeap()
+	var events []string
+	var states [][]int
+	h.SetTracer(func(event string, state []int) {
+		events = append(events, event)
+		states = append(states, state)
+	})
+	h.Push(1)
+	h.Push(5)
+	h.Pop()
+	h.Pop()
+	h.Pop() // empty: nothing changes, nothing traced
+
+	wantEvents := []string{"push", "push", "pop", "pop"}
+	wantStates := [][]int{{1}, {5, 1}, {1}, {}}
+	if len(events) != len(wantEvents) {
+		t.Fatalf("traced %v, want %v", events, wantEvents)
+	}
+	for i := range wantEvents {
+		if events[i] != wantEvents[i] || !equalInts(states[i], wantStates[i]) {
+			t.Fatalf("event %d = %s %v, want %s %v", i, events[i], states[i], wantEvents[i], wantStates[i])
+		}
+	}
+
+	h.Push(3)
+	states[len(states)-1][0] = 100
+	if x, _ := h.Peek(); x != 3 {
+		t.Fatalf("Peek = %d after editing the traced state: tracer aliases the heap", x)
+	}
+	h.SetTracer(nil)
+	h.Push(4)
+	if len(events) != len(wantEvents)+1 {
+		t.Fatalf("tracer still called after SetTracer(nil): %v", events)
+	}
+}
+
+func TestMaxSlidingWindowNegativeOne(t *testing.T) {
+	// -1 used to be the empty-heap sentinel; it must flow through like any
+	// other value.
+	got := maxSlidingWindow([]int{-1, -1, -3, -1, -5, -6}, 2)
+	if want := []int{-1, -1, -1, -1, -5}; !equalInts(got, want) {
+		t.Fatalf("maxSlidingWindow = %v, want %v", got, want)
+	}
+}
+
+func TestMaxSlidingWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		nums []int
+		k    int
+		want []int
+	}{
+		{"example", []int{9, 10, 9, -7, -4, 8, 2, -6}, 5, []int{10, 10, 9, 8}},
+		{"stale below top", []int{3, 5, 1, 0}, 2, []int{5, 5, 1}},
+		{"repeated values", []int{4, 4, 4, 1, 4, 1, 1}, 3, []int{4, 4, 4, 4, 4}},
+		{"strictly decreasing", []int{9, 7, 5, 3, 1}, 2, []int{9, 7, 5, 3}},
+		{"window of one", []int{2, -1, 3}, 1, []int{2, -1, 3}},
+		{"whole slice", []int{1, 3, -1, -3, 5, 3, 6, 7}, 8, []int{7}},
+		{"all equal", []int{1, 1, 1, 1}, 2, []int{1, 1, 1}},
+		{"alternating maxima", []int{5, 3, 5, 3, 5}, 2, []int{5, 5, 5, 5}},
+		{"alternating maxima k=3", []int{5, 3, 5, 3, 5}, 3, []int{5, 5, 5}},
+		{"max repeats then leaves", []int{7, 7, 2, 7, 1, 1, 1}, 3, []int{7, 7, 7, 7, 1}},
+		{"duplicate leaves while copy stays", []int{4, 2, 4, 1, 0}, 2, []int{4, 4, 4, 1}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := maxSlidingWindow(tt.nums, tt.k)
+			if !equalInts(got, tt.want) {
+				t.Fatalf("maxSlidingWindow(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMaxSlidingWindowRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, 1+r.Intn(40))
+		for i := range nums {
+			nums[i] = r.Intn(10) - 5
+		}
+		k := 1 + r.Intn(len(nums))
+		if got, want := maxSlidingWindow(nums, k), bruteMaxWindow(nums, k); !equalInts(got, want) {
+			t.Fatalf("maxSlidingWindow(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+}
+
+// assertHeap fails the test if any parent in h ranks below one of its
+// children.
+func assertHeap[T any](t *testing.T, h *Heap[T]) {
+	t.Helper()
+	if err := h.CheckInvariant(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func bruteMaxWindow(nums []int, k int) []int {
+	var res []int
+	for i := 0; i+k <= len(nums); i++ {
+		m := nums[i]
+		for _, x := range nums[i : i+k] {
+			if x > m {
+				m = x
+			}
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkHeapPush(b *testing.B) {
+	const n = 10000
+	b.Run("default", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h := NewHeap()
+			for x := 0; x < n; x++ {
+				h.Push(x)
+			}
+		}
+	})
+	b.Run("presized", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h := NewHeapWithCapacity(n)
+			for x := 0; x < n; x++ {
+				h.Push(x)
+			}
+		}
+	})
+}
+
+func BenchmarkHeapWithCapacity(b *testing.B) {
+	for _, k := range []int{1000, 5000} {
+		b.Run(fmt.Sprint("k=", k, "/default"), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := NewHeap()
+				for x := 0; x < k; x++ {
+					h.Push(x)
+				}
+			}
+		})
+		b.Run(fmt.Sprint("k=", k, "/capacity"), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				h := NewHeap(WithCapacity(k))
+				for x := 0; x < k; x++ {
+					h.Push(x)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHeapBuild(b *testing.B) {
+	const n = 50000
+	xs := rand.New(rand.NewSource(5)).Perm(n)
+	buf := make([]int, n)
+	b.Run("push", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h := NewHeapWithCapacity(n)
+			for _, x := range xs {
+				h.Push(x)
+			}
+		}
+	})
+	b.Run("from-slice", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(buf, xs)
+			NewHeapFromSlice(buf)
+		}
+	})
+}
+
+func BenchmarkHeapArity(b *testing.B) {
+	const n = 200000
+	xs := rand.New(rand.NewSource(56)).Perm(n)
+	for _, d := range []int{2, 4, 8} {
+		b.Run(fmt.Sprint("d=", d), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := newHeap(n, greater[int], WithArity(d))
+				for _, x := range xs {
+					h.Push(x)
+				}
+				for !h.IsEmpty() {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHeapPushPop(b *testing.B) {
+	const n = 10000
+	base := Heapify(rand.New(rand.NewSource(30)).Perm(n))
+	xs := rand.New(rand.NewSource(60)).Perm(n)
+	b.Run("pushpop", func(b *testing.B) {
+		h := base.Clone()
+		for i := 0; i < b.N; i++ {
+			h.PushPop(xs[i%n])
+		}
+	})
+	b.Run("push+pop", func(b *testing.B) {
+		h := base.Clone()
+		for i := 0; i < b.N; i++ {
+			h.Push(xs[i%n])
+			h.Pop()
+		}
+	})
+}
+
+func BenchmarkHeapStats(b *testing.B) {
+	xs := rand.New(rand.NewSource(36)).Perm(10000)
+	for _, tc := range []struct {
+		name string
+		opts []Option
+	}{
+		{"disabled", nil},
+		{"enabled", []Option{WithStats()}},
+	} {
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				h := NewHeap(append(tc.opts, WithCapacity(len(xs)))...)
+				for _, x := range xs {
+					h.Push(x)
+				}
+				for !h.IsEmpty() {
+					h.Pop()
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkHeapMerge(b *testing.B) {
+	const n = 20000
+	r := rand.New(rand.NewSource(8))
+	xs, ys := r.Perm(n), r.Perm(n)
+	b.Run("merge", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h, other := Heapify(xs), Heapify(ys)
+			h.Merge(&other)
+		}
+	})
+	b.Run("push-loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h, other := Heapify(xs), Heapify(ys)
+			for !other.IsEmpty() {
+				x, _ := other.Pop()
+				h.Push(x)
+			}
+		}
+	})
+}
+
+func BenchmarkHeapReuse(b *testing.B) {
+	const batches, n = 16, 2000
+	xs := rand.New(rand.NewSource(9)).Perm(n)
+	b.Run("new-per-batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < batches; j++ {
+				h := NewHeap()
+				for _, x := range xs {
+					h.Push(x)
+				}
+			}
+		}
+	})
+	b.Run("clear-per-batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			h := NewHeap()
+			for j := 0; j < batches; j++ {
+				h.Clear()
+				for _, x := range xs {
+					h.Push(x)
+				}
+			}
+		}
+	})
+}
+
+func BenchmarkHeapPushAllCrossover(b *testing.B) {
+	const n = 4096
+	r := rand.New(rand.NewSource(11))
+	base := r.Perm(n)
+	for _, m := range []int{16, 256, 2048, 4096, 16384} {
+		batch := make([]int, m)
+		for i := range batch {
+			batch[i] = r.Intn(n)
+		}
+		for _, path := range []struct {
+			name string
+			push func(h *IntHeap, xs []int)
+		}{
+			{"each", (*IntHeap).pushEach},
+			{"rebuild", (*IntHeap).pushRebuild},
+		} {
+			b.Run(fmt.Sprintf("m=%d/%s", m, path.name), func(b *testing.B) {
+				for i := 0; i < b.N; i++ {
+					b.StopTimer()
+					h := Heapify(base)
+					b.StartTimer()
+					path.push(&h, batch)
+				}
+			})
+		}
+	}
+}
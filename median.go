@@ -0,0 +1,150 @@
+package goproject
+
+// WindowMedian tracks the median of the last k values of a stream. The
+// lower half lives in a max-heap and the upper half in a min-heap, both
+// LazyDeleteHeaps, so a value leaving the window is deleted from its half
+// in O(1) and swept out later. Memory stays O(k) however long the stream.
+type WindowMedian struct {
+	k      int
+	window []int // ring buffer of the last k values
+	next   int   // oldest slot of window once it is full
+	lo, hi LazyDeleteHeap[int]
+}
+
+// NewWindowMedian returns a WindowMedian over the last k values. A k < 1 is
+// treated as 1.
+func NewWindowMedian(k int) *WindowMedian {
+	k = max(k, 1)
+	return &WindowMedian{
+		k:      k,
+		window: make([]int, 0, k),
+		lo:     NewLazyDeleteHeap[int](),
+		hi:     NewLazyDeleteHeap[int](WithMinOrdering()),
+	}
+}
+
+// Add appends x to the stream, expiring the oldest value once more than k
+// have arrived.
+func (w *WindowMedian) Add(x int) {
+	if len(w.window) < w.k {
+		w.window = append(w.window, x)
+	} else {
+		old := w.window[w.next]
+		w.window[w.next] = x
+		w.next = (w.next + 1) % w.k
+		w.evict(old)
+	}
+	if top, ok := w.lo.Peek(); ok && x <= top {
+		w.lo.Push(x)
+	} else {
+		w.hi.Push(x)
+	}
+	w.rebalance()
+}
+
+// Median returns the median of the values currently in the window, which
+// holds fewer than k values until k have arrived. For an even count it is
+// the mean of the two middle values; for an empty window it is 0.
+func (w *WindowMedian) Median() float64 {
+	return twoHeapMedian(&w.lo, &w.hi)
+}
+
+// evict deletes the expired x. Every value in lo is at most lo's top and
+// every value in hi at least that, so x can be taken from lo exactly when
+// it does not exceed lo's top.
+func (w *WindowMedian) evict(x int) {
+	if top, ok := w.lo.Peek(); ok && x <= top {
+		w.lo.Delete(x)
+	} else {
+		w.hi.Delete(x)
+	}
+}
+
+// rebalance restores lo.Len() == hi.Len() or lo.Len() == hi.Len()+1 by
+// moving tops across.
+func (w *WindowMedian) rebalance() {
+	for w.lo.Len() > w.hi.Len()+1 {
+		x, _ := w.lo.Pop()
+		w.hi.Push(x)
+	}
+	for w.hi.Len() > w.lo.Len() {
+		x, _ := w.hi.Pop()
+		w.lo.Push(x)
+	}
+}
+
+// SlidingWindowMedian returns the median of every window of k consecutive
+// elements of nums, the mean of the two middle values when k is even. It
+// returns an empty slice when k <= 0 or k > len(nums).
+func SlidingWindowMedian(nums []int, k int) []float64 {
+	if k <= 0 || k > len(nums) {
+		return []float64{}
+	}
+	res := make([]float64, 0, len(nums)-k+1)
+	w := NewWindowMedian(k)
+	for i, x := range nums {
+		w.Add(x)
+		if i >= k-1 {
+			res = append(res, w.Median())
+		}
+	}
+	return res
+}
+
+// MedianFinder tracks the median of every value added so far, keeping the
+// lower half in a max-heap and the upper half in a min-heap whose sizes
+// differ by at most one.
+type MedianFinder struct {
+	lo, hi Heap[int]
+}
+
+// NewMedianFinder returns an empty MedianFinder.
+func NewMedianFinder() *MedianFinder {
+	return &MedianFinder{lo: NewHeap(), hi: NewMinHeap()}
+}
+
+// AddNum adds x to the stream in O(log n).
+func (m *MedianFinder) AddNum(x int) {
+	if top, ok := m.lo.Peek(); ok && x > top {
+		m.hi.Push(x)
+	} else {
+		m.lo.Push(x)
+	}
+	switch {
+	case m.lo.Len() > m.hi.Len()+1:
+		x, _ := m.lo.Pop()
+		m.hi.Push(x)
+	case m.hi.Len() > m.lo.Len():
+		x, _ := m.hi.Pop()
+		m.lo.Push(x)
+	}
+}
+
+// FindMedian returns the median of the values added so far: the middle
+// value for an odd count, the mean of the two middle values for an even
+// one, and 0 before anything has been added.
+func (m *MedianFinder) FindMedian() float64 {
+	return twoHeapMedian(&m.lo, &m.hi)
+}
+
+// halfHeap is the view twoHeapMedian needs of either half: a Heap[int] or
+// a LazyDeleteHeap[int].
+type halfHeap interface {
+	Peek() (int, bool)
+	Len() int
+}
+
+// twoHeapMedian returns the median held by a max-heap lo of the lower half
+// and a min-heap hi of the upper half, where lo holds as many values as hi
+// or one more.
+func twoHeapMedian(lo, hi halfHeap) float64 {
+	a, ok := lo.Peek()
+	if !ok {
+		return 0
+	}
+	if lo.Len() > hi.Len() {
+		return float64(a)
+	}
+	b, _ := hi.Peek()
+	return (float64(a) + float64(b)) / 2
+}
@@ -0,0 +1,142 @@
+package goproject
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestWindowMedian(t *testing.T) {
+	r := rand.New(rand.NewSource(28))
+	for iter := 0; iter < 100; iter++ {
+		k := 1 + r.Intn(8)
+		w := NewWindowMedian(k)
+		var stream []int
+		for n := 0; n < 60; n++ {
+			x := r.Intn(10) - 5
+			stream = append(stream, x)
+			w.Add(x)
+			window := stream[max(0, len(stream)-k):]
+			if got, want := w.Median(), sortedMedian(window); got != want {
+				t.Fatalf("k=%d: Median of %v = %v, want %v", k, window, got, want)
+			}
+		}
+	}
+}
+
+func TestWindowMedianMemory(t *testing.T) {
+	// On a monotone stream, such as a cumulative energy counter, expired
+	// values never reach the top of their half on their own.
+	for _, step := range []int{1, -1} {
+		const k = 5
+		w := NewWindowMedian(k)
+		for i := 0; i < 100000; i++ {
+			w.Add(i * step)
+			if held := w.lo.h.Len() + w.hi.h.Len(); held > 4*k {
+				t.Fatalf("step %d: after %d values the halves hold %d for a window of %d", step, i+1, held, k)
+			}
+			if i >= k-1 {
+				if got, want := w.Median(), float64((i-2)*step); got != want {
+					t.Fatalf("step %d: median after %d values = %v, want %v", step, i+1, got, want)
+				}
+			}
+		}
+		if n := len(w.lo.pending) + len(w.hi.pending); n > 2*k {
+			t.Fatalf("step %d: %d values still pending", step, n)
+		}
+	}
+}
+
+func TestWindowMedianExample(t *testing.T) {
+	w := NewWindowMedian(3)
+	if got := w.Median(); got != 0 {
+		t.Fatalf("Median of an empty window = %v, want 0", got)
+	}
+	want := []float64{1, 2, 1, -1, -1, 3, 5, 6}
+	for i, x := range []int{1, 3, -1, -3, 5, 3, 6, 7} {
+		w.Add(x)
+		if got := w.Median(); got != want[i] {
+			t.Fatalf("Median after %d adds = %v, want %v", i+1, got, want[i])
+		}
+	}
+	even := NewWindowMedian(2)
+	for _, x := range []int{2, 2, 7} {
+		even.Add(x)
+	}
+	if got := even.Median(); got != 4.5 {
+		t.Fatalf("even window Median = %v, want 4.5", got)
+	}
+}
+
+func TestSlidingWindowMedian(t *testing.T) {
+	got := SlidingWindowMedian([]int{1, 3, -1, -3, 5, 3, 6, 7}, 3)
+	want := []float64{1, -1, -1, 3, 5, 6}
+	if len(got) != len(want) {
+		t.Fatalf("SlidingWindowMedian example = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SlidingWindowMedian example = %v, want %v", got, want)
+		}
+	}
+	for _, k := range []int{0, -1, 9} {
+		if got := SlidingWindowMedian([]int{1, 2, 3}, k); got == nil || len(got) != 0 {
+			t.Fatalf("SlidingWindowMedian with k=%d = %#v, want empty", k, got)
+		}
+	}
+
+	r := rand.New(rand.NewSource(40))
+	for _, n := range []int{1, 7, 100, 10000} {
+		nums := make([]int, n)
+		for i := range nums {
+			// A narrow range packs duplicates around the median.
+			nums[i] = r.Intn(7) - 3
+		}
+		for _, k := range []int{1, 2, 3, 4, 17, 64} {
+			if k > n {
+				continue
+			}
+			got := SlidingWindowMedian(nums, k)
+			for i := range got {
+				if want := sortedMedian(nums[i : i+k]); got[i] != want {
+					t.Fatalf("n=%d k=%d: window %d median = %v, want %v", n, k, i, got[i], want)
+				}
+			}
+		}
+	}
+}
+
+func sortedMedian(xs []int) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	s := append([]int(nil), xs...)
+	sort.Ints(s)
+	if n := len(s); n%2 == 1 {
+		return float64(s[n/2])
+	}
+	return (float64(s[len(s)/2-1]) + float64(s[len(s)/2])) / 2
+}
+
+func TestMedianFinder(t *testing.T) {
+	m := NewMedianFinder()
+	if got := m.FindMedian(); got != 0 {
+		t.Fatalf("FindMedian before any AddNum = %v, want 0", got)
+	}
+	r := rand.New(rand.NewSource(29))
+	var seen []int
+	for n := 0; n < 500; n++ {
+		x := r.Intn(40) - 20
+		m.AddNum(x)
+		seen = append(seen, x)
+		if r.Intn(3) > 0 {
+			continue
+		}
+		if got, want := m.FindMedian(), sortedMedian(seen); got != want {
+			t.Fatalf("FindMedian after %d adds = %v, want %v", len(seen), got, want)
+		}
+		if d := m.lo.Len() - m.hi.Len(); d < 0 || d > 1 {
+			t.Fatalf("halves unbalanced: %d vs %d", m.lo.Len(), m.hi.Len())
+		}
+	}
+}
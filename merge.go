@@ -0,0 +1,28 @@
+package goproject
+
+// MergeSorted merges ascending slices into one ascending slice in
+// O(n log k) for n elements across k lists. A min-heap holds one cursor per
+// non-empty list; the smallest head is emitted and its cursor advanced in
+// place with Replace.
+func MergeSorted(lists ...[]int) []int {
+	type cursor struct{ value, list, idx int }
+	n := 0
+	h := NewHeapFunc(func(a, b cursor) bool { return a.value < b.value })
+	for i, l := range lists {
+		n += len(l)
+		if len(l) > 0 {
+			h.Push(cursor{l[0], i, 0})
+		}
+	}
+	res := make([]int, 0, n)
+	for !h.IsEmpty() {
+		c, _ := h.Peek()
+		res = append(res, c.value)
+		if next := c.idx + 1; next < len(lists[c.list]) {
+			h.Replace(cursor{lists[c.list][next], c.list, next})
+		} else {
+			h.Pop()
+		}
+	}
+	return res
+}
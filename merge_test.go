@@ -0,0 +1,43 @@
+package goproject
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMergeSorted(t *testing.T) {
+	tests := [][][]int{
+		nil,
+		{{}},
+		{{}, nil, {}},
+		{{1, 4, 9}},
+		{{1, 4, 9}, {}},
+		{{1, 1, 3}, {1, 2}, {0, 5, 5, 5}},
+		{{-3, 0}, {-7}, {2, 8, 10}, {-3}},
+	}
+	r := rand.New(rand.NewSource(31))
+	for iter := 0; iter < 50; iter++ {
+		lists := make([][]int, r.Intn(12))
+		for i := range lists {
+			l := make([]int, r.Intn(20))
+			for j := range l {
+				l[j] = r.Intn(50) - 25
+			}
+			sort.Ints(l)
+			lists[i] = l
+		}
+		tests = append(tests, lists)
+	}
+	for _, lists := range tests {
+		var want []int
+		for _, l := range lists {
+			want = append(want, l...)
+		}
+		sort.Ints(want)
+		got := MergeSorted(lists...)
+		if got == nil || !equalInts(got, want) {
+			t.Fatalf("MergeSorted(%v) = %#v, want %v", lists, got, want)
+		}
+	}
+}
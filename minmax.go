@@ -0,0 +1,163 @@
+package goproject
+
+import (
+	"cmp"
+	"math/bits"
+)
+
+// MinMaxHeap is a double-ended priority queue: both the smallest and the
+// largest element can be read in O(1) and removed in O(log n). It uses the
+// min-max heap layout, where nodes on even levels (the root is level 0) are
+// no larger than anything below them and nodes on odd levels no smaller.
+// Floating-point NaNs are not ordered and must not be pushed. The zero
+// MinMaxHeap is empty and ready to use.
+type MinMaxHeap[T cmp.Ordered] struct {
+	c []T
+}
+
+func (h *MinMaxHeap[T]) Len() int {
+	return len(h.c)
+}
+
+// Push adds x in O(log n).
+func (h *MinMaxHeap[T]) Push(x T) {
+	h.c = append(h.c, x)
+	i := len(h.c) - 1
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	switch {
+	case onMinLevel(i) && h.c[i] > h.c[parent]:
+		h.swap(i, parent)
+		h.bubbleUp(parent, false)
+	case !onMinLevel(i) && h.c[i] < h.c[parent]:
+		h.swap(i, parent)
+		h.bubbleUp(parent, true)
+	default:
+		h.bubbleUp(i, onMinLevel(i))
+	}
+}
+
+// PeekMin returns the smallest element. ok is false if the heap is empty.
+func (h *MinMaxHeap[T]) PeekMin() (x T, ok bool) {
+	if len(h.c) == 0 {
+		return x, false
+	}
+	return h.c[0], true
+}
+
+// PeekMax returns the largest element. ok is false if the heap is empty.
+func (h *MinMaxHeap[T]) PeekMax() (x T, ok bool) {
+	if len(h.c) == 0 {
+		return x, false
+	}
+	return h.c[h.maxIndex()], true
+}
+
+// PopMin removes and returns the smallest element; see PeekMin.
+func (h *MinMaxHeap[T]) PopMin() (x T, ok bool) {
+	if len(h.c) == 0 {
+		return x, false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax removes and returns the largest element; see PeekMax.
+func (h *MinMaxHeap[T]) PopMax() (x T, ok bool) {
+	if len(h.c) == 0 {
+		return x, false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// maxIndex returns where the largest element sits: the root if it is
+// alone, otherwise the larger of its children. The heap must not be empty.
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch {
+	case len(h.c) == 1:
+		return 0
+	case len(h.c) == 2 || h.c[1] >= h.c[2]:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// removeAt moves the last element into slot i, which must hold the minimum
+// or the maximum, and trickles it down.
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	res := h.c[i]
+	last := len(h.c) - 1
+	h.c[i] = h.c[last]
+	var zero T
+	h.c[last] = zero
+	h.c = h.c[:last]
+	if i < last {
+		h.trickleDown(i)
+	}
+	return res
+}
+
+// onMinLevel reports whether index i is on an even, min, level.
+func onMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+// minMaxBefore reports whether a belongs above b on a min level (minLevel
+// is true) or a max level.
+func minMaxBefore[T cmp.Ordered](a, b T, minLevel bool) bool {
+	if minLevel {
+		return a < b
+	}
+	return a > b
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.c[i], h.c[j] = h.c[j], h.c[i]
+}
+
+// bubbleUp moves the element at i up through its grandparents, which are
+// on the same kind of level.
+func (h *MinMaxHeap[T]) bubbleUp(i int, minLevel bool) {
+	for i > 2 {
+		grand := ((i-1)/2 - 1) / 2
+		if !minMaxBefore(h.c[i], h.c[grand], minLevel) {
+			return
+		}
+		h.swap(i, grand)
+		i = grand
+	}
+}
+
+// trickleDown moves the element at i down until it fits, looking at both
+// children and grandchildren at each step.
+func (h *MinMaxHeap[T]) trickleDown(i int) {
+	minLevel := onMinLevel(i)
+	for {
+		first := 2*i + 1
+		if first >= len(h.c) {
+			return
+		}
+		// m is the best of the up to two children and four grandchildren.
+		m := first
+		for _, j := range []int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if j < len(h.c) && minMaxBefore(h.c[j], h.c[m], minLevel) {
+				m = j
+			}
+		}
+		if !minMaxBefore(h.c[m], h.c[i], minLevel) {
+			return
+		}
+		h.swap(i, m)
+		if m <= first+1 {
+			// m is a child, so no grandchild ranked above it and there
+			// is nothing further down to fix.
+			return
+		}
+		if parent := (m - 1) / 2; minMaxBefore(h.c[parent], h.c[m], minLevel) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
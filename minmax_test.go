@@ -0,0 +1,91 @@
+package goproject
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestMinMaxHeap(t *testing.T) {
+	var h MinMaxHeap[int]
+	if _, ok := h.PeekMin(); ok {
+		t.Fatalf("PeekMin on an empty heap reported ok")
+	}
+	if _, ok := h.PopMax(); ok {
+		t.Fatalf("PopMax on an empty heap reported ok")
+	}
+	h.Push(4)
+	if lo, _ := h.PeekMin(); lo != 4 {
+		t.Fatalf("PeekMin of one element = %d", lo)
+	}
+	if hi, _ := h.PeekMax(); hi != 4 {
+		t.Fatalf("PeekMax of one element = %d", hi)
+	}
+
+	r := rand.New(rand.NewSource(41))
+	for iter := 0; iter < 50; iter++ {
+		var h MinMaxHeap[int]
+		var ref []int
+		for op := 0; op < 300; op++ {
+			if r.Intn(3) > 0 || len(ref) == 0 {
+				x := r.Intn(50) - 25
+				h.Push(x)
+				ref = append(ref, x)
+			} else {
+				sort.Ints(ref)
+				if r.Intn(2) == 0 {
+					got, ok := h.PopMin()
+					if !ok || got != ref[0] {
+						t.Fatalf("PopMin = (%d, %v), want %d", got, ok, ref[0])
+					}
+					ref = ref[1:]
+				} else {
+					got, ok := h.PopMax()
+					if !ok || got != ref[len(ref)-1] {
+						t.Fatalf("PopMax = (%d, %v), want %d", got, ok, ref[len(ref)-1])
+					}
+					ref = ref[:len(ref)-1]
+				}
+			}
+			checkMinMaxHeap(t, &h)
+			if h.Len() != len(ref) {
+				t.Fatalf("Len = %d, want %d", h.Len(), len(ref))
+			}
+			if len(ref) > 0 {
+				lo, _ := h.PeekMin()
+				hi, _ := h.PeekMax()
+				sort.Ints(ref)
+				if lo != ref[0] || hi != ref[len(ref)-1] {
+					t.Fatalf("PeekMin/PeekMax = %d/%d, want %d/%d", lo, hi, ref[0], ref[len(ref)-1])
+				}
+			}
+		}
+	}
+
+	var words MinMaxHeap[string]
+	for _, w := range []string{"pear", "fig", "apple", "kiwi"} {
+		words.Push(w)
+	}
+	if lo, _ := words.PopMin(); lo != "apple" {
+		t.Fatalf("string PopMin = %q", lo)
+	}
+	if hi, _ := words.PopMax(); hi != "pear" {
+		t.Fatalf("string PopMax = %q", hi)
+	}
+}
+
+// checkMinMaxHeap verifies that every node on a min level is no larger than
+// its descendants and every node on a max level no smaller.
+func checkMinMaxHeap(t *testing.T, h *MinMaxHeap[int]) {
+	t.Helper()
+	for i := 1; i < h.Len(); i++ {
+		for a := (i - 1) / 2; ; a = (a - 1) / 2 {
+			if onMinLevel(a) && h.c[i] < h.c[a] || !onMinLevel(a) && h.c[i] > h.c[a] {
+				t.Fatalf("min-max order broken between index %d (%d) and ancestor %d (%d): %v", i, h.c[i], a, h.c[a], h.c)
+			}
+			if a == 0 {
+				break
+			}
+		}
+	}
+}
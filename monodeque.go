@@ -0,0 +1,55 @@
+package goproject
+
+// MonoDeque is a monotonic deque for windowed maxima. Each pushed value is
+// numbered by its position in the stream, 0 for the first. The deque keeps
+// only values that can still become the maximum: each is strictly larger
+// than everything pushed after it, so the front is the maximum of whatever
+// has not been evicted. Pushes and evictions are amortised O(1). The zero
+// MonoDeque is empty and ready to use.
+type MonoDeque struct {
+	entries Deque[monoEntry] // values strictly decreasing front to back
+	next    int              // index the next pushed value gets
+}
+
+type monoEntry struct {
+	i, x int
+}
+
+// PushBack appends x, dropping the values behind which it can never be
+// the maximum, and returns the stream index it was given.
+func (d *MonoDeque) PushBack(x int) int {
+	for back, ok := d.entries.Back(); ok && back.x <= x; back, ok = d.entries.Back() {
+		d.entries.PopBack()
+	}
+	d.entries.PushBack(monoEntry{d.next, x})
+	d.next++
+	return d.next - 1
+}
+
+// EvictBefore drops every value whose stream index is below i, e.g.
+// i = n-k to keep a window of the last k after n pushes.
+func (d *MonoDeque) EvictBefore(i int) {
+	for front, ok := d.entries.Front(); ok && front.i < i; front, ok = d.entries.Front() {
+		d.entries.PopFront()
+	}
+}
+
+// Max returns the largest value not yet evicted. ok is false if there is
+// none.
+func (d *MonoDeque) Max() (x int, ok bool) {
+	e, ok := d.entries.Front()
+	return e.x, ok
+}
+
+// PopFront removes and returns the front value, the current maximum; see
+// Max.
+func (d *MonoDeque) PopFront() (x int, ok bool) {
+	e, ok := d.entries.PopFront()
+	return e.x, ok
+}
+
+// Len returns the number of values kept as candidates, which may be fewer
+// than were pushed and not evicted.
+func (d *MonoDeque) Len() int {
+	return d.entries.Len()
+}
@@ -0,0 +1,56 @@
+package goproject
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMonoDeque(t *testing.T) {
+	var d MonoDeque
+	if _, ok := d.Max(); ok {
+		t.Fatalf("Max on an empty deque reported ok")
+	}
+	if _, ok := d.PopFront(); ok {
+		t.Fatalf("PopFront on an empty deque reported ok")
+	}
+	for want, x := range []int{3, 1, 2} {
+		if i := d.PushBack(x); i != want {
+			t.Fatalf("PushBack(%d) = index %d, want %d", x, i, want)
+		}
+	}
+	// 1 was dropped as soon as 2 arrived.
+	if m, _ := d.Max(); m != 3 || d.Len() != 2 {
+		t.Fatalf("Max = %d, Len %d; want 3, 2", m, d.Len())
+	}
+	d.EvictBefore(1)
+	if m, _ := d.Max(); m != 2 {
+		t.Fatalf("Max after evicting index 0 = %d, want 2", m)
+	}
+	if x, ok := d.PopFront(); !ok || x != 2 || d.Len() != 0 {
+		t.Fatalf("PopFront = (%d, %v), Len %d", x, ok, d.Len())
+	}
+
+	r := rand.New(rand.NewSource(43))
+	for iter := 0; iter < 100; iter++ {
+		var d MonoDeque
+		k := 1 + r.Intn(10)
+		var nums []int
+		for i := 0; i < 80; i++ {
+			x := r.Intn(15) - 7
+			nums = append(nums, x)
+			d.PushBack(x)
+			d.EvictBefore(i - k + 1)
+			window := nums[max(0, i-k+1):]
+			want := window[0]
+			for _, v := range window {
+				want = max(want, v)
+			}
+			if got, ok := d.Max(); !ok || got != want {
+				t.Fatalf("k=%d: Max of %v = (%d, %v), want %d", k, window, got, ok, want)
+			}
+			if d.Len() > len(window) {
+				t.Fatalf("deque holds %d candidates for a window of %d", d.Len(), len(window))
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package goproject
+
+// NextGreater returns, for each index i, the index of the nearest element
+// to the right of i that is strictly greater than nums[i], or -1 if there
+// is none. A monotonic stack of indices still waiting for their answer
+// makes it O(n).
+func NextGreater(nums []int) []int {
+	res := make([]int, len(nums))
+	var stack []int // indices whose values are non-increasing bottom to top
+	for i, x := range nums {
+		for len(stack) > 0 && nums[stack[len(stack)-1]] < x {
+			res[stack[len(stack)-1]] = i
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, i)
+	}
+	for _, i := range stack {
+		res[i] = -1
+	}
+	return res
+}
+
+// PrevSmaller returns, for each index i, the index of the nearest element
+// to the left of i that is strictly smaller than nums[i], or -1 if there is
+// none, in O(n).
+func PrevSmaller(nums []int) []int {
+	res := make([]int, len(nums))
+	var stack []int // indices whose values are strictly increasing bottom to top
+	for i, x := range nums {
+		for len(stack) > 0 && nums[stack[len(stack)-1]] >= x {
+			stack = stack[:len(stack)-1]
+		}
+		res[i] = -1
+		if len(stack) > 0 {
+			res[i] = stack[len(stack)-1]
+		}
+		stack = append(stack, i)
+	}
+	return res
+}
+
+// nextSmaller returns, for each index i, the index of the nearest element
+// to the right that is strictly smaller than nums[i], or len(nums) if there
+// is none.
+func nextSmaller(nums []int) []int {
+	res := make([]int, len(nums))
+	var stack []int // indices whose values are non-decreasing bottom to top
+	for i, x := range nums {
+		for len(stack) > 0 && nums[stack[len(stack)-1]] > x {
+			res[stack[len(stack)-1]] = i
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, i)
+	}
+	for _, i := range stack {
+		res[i] = len(nums)
+	}
+	return res
+}
+
+// LargestRectangleArea returns the area of the largest rectangle that fits
+// under a histogram of unit-width bars, in O(n). The best rectangle of
+// height heights[i] spans from just after its previous smaller bar to just
+// before its next smaller one.
+func LargestRectangleArea(heights []int) int {
+	prev, next := PrevSmaller(heights), nextSmaller(heights)
+	best := 0
+	for i, h := range heights {
+		best = max(best, h*(next[i]-prev[i]-1))
+	}
+	return best
+}
@@ -0,0 +1,95 @@
+package goproject
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestNextGreaterPrevSmaller(t *testing.T) {
+	tests := []struct {
+		name       string
+		nums       []int
+		next, prev []int
+	}{
+		{"empty", nil, []int{}, []int{}},
+		{"increasing", []int{1, 2, 3, 4}, []int{1, 2, 3, -1}, []int{-1, 0, 1, 2}},
+		{"decreasing", []int{4, 3, 2, 1}, []int{-1, -1, -1, -1}, []int{-1, -1, -1, -1}},
+		{"constant", []int{5, 5, 5}, []int{-1, -1, -1}, []int{-1, -1, -1}},
+		{"mixed", []int{2, 1, 2, 4, 3}, []int{3, 2, 3, -1, -1}, []int{-1, -1, 1, 2, 2}},
+	}
+	for _, tt := range tests {
+		if got := NextGreater(tt.nums); got == nil || !equalInts(got, tt.next) {
+			t.Errorf("%s: NextGreater(%v) = %v, want %v", tt.name, tt.nums, got, tt.next)
+		}
+		if got := PrevSmaller(tt.nums); got == nil || !equalInts(got, tt.prev) {
+			t.Errorf("%s: PrevSmaller(%v) = %v, want %v", tt.name, tt.nums, got, tt.prev)
+		}
+	}
+
+	r := rand.New(rand.NewSource(45))
+	for iter := 0; iter < 100; iter++ {
+		nums := make([]int, r.Intn(40))
+		for i := range nums {
+			nums[i] = r.Intn(8)
+		}
+		next, prev := NextGreater(nums), PrevSmaller(nums)
+		for i, x := range nums {
+			wantNext := -1
+			for j := i + 1; j < len(nums); j++ {
+				if nums[j] > x {
+					wantNext = j
+					break
+				}
+			}
+			wantPrev := -1
+			for j := i - 1; j >= 0; j-- {
+				if nums[j] < x {
+					wantPrev = j
+					break
+				}
+			}
+			if next[i] != wantNext || prev[i] != wantPrev {
+				t.Fatalf("%v at %d: NextGreater %d, PrevSmaller %d; want %d, %d", nums, i, next[i], prev[i], wantNext, wantPrev)
+			}
+		}
+	}
+}
+
+func TestLargestRectangleArea(t *testing.T) {
+	tests := []struct {
+		heights []int
+		want    int
+	}{
+		{[]int{2, 1, 5, 6, 2, 3}, 10},
+		{[]int{1, 2, 3, 4, 5}, 9},
+		{[]int{5, 4, 3, 2, 1}, 9},
+		{[]int{7}, 7},
+		{[]int{3, 3, 3, 3}, 12},
+		{[]int{2, 0, 2}, 2},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := LargestRectangleArea(tt.heights); got != tt.want {
+			t.Errorf("LargestRectangleArea(%v) = %d, want %d", tt.heights, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(46))
+	for iter := 0; iter < 100; iter++ {
+		heights := make([]int, r.Intn(30))
+		for i := range heights {
+			heights[i] = r.Intn(10)
+		}
+		want := 0
+		for i := range heights {
+			low := heights[i]
+			for j := i; j < len(heights); j++ {
+				low = min(low, heights[j])
+				want = max(want, low*(j-i+1))
+			}
+		}
+		if got := LargestRectangleArea(heights); got != want {
+			t.Fatalf("LargestRectangleArea(%v) = %d, want %d", heights, got, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package goproject
+
+// Item is a value queued with an integer priority.
+type Item[V any] struct {
+	Value    V
+	Priority int
+}
+
+// PQ is a priority queue of arbitrary values. Higher priorities come out
+// first, or lower ones for a queue built by NewMinPQ; items with equal
+// priority come out in the order they were pushed.
+type PQ[V any] struct {
+	h   Heap[pqEntry[V]]
+	seq uint64
+}
+
+type pqEntry[V any] struct {
+	Item[V]
+	seq uint64
+}
+
+// NewPQ returns an empty priority queue that pops the highest priority
+// first.
+func NewPQ[V any]() PQ[V] {
+	return newPQ[V](func(a, b int) bool { return a > b })
+}
+
+// NewMinPQ returns an empty priority queue that pops the lowest priority
+// first.
+func NewMinPQ[V any]() PQ[V] {
+	return newPQ[V](func(a, b int) bool { return a < b })
+}
+
+func newPQ[V any](before func(a, b int) bool) PQ[V] {
+	return PQ[V]{h: NewHeapFunc(func(a, b pqEntry[V]) bool {
+		if a.Priority != b.Priority {
+			return before(a.Priority, b.Priority)
+		}
+		return a.seq < b.seq
+	})}
+}
+
+// Push queues value with priority.
+func (q *PQ[V]) Push(value V, priority int) {
+	q.h.Push(pqEntry[V]{Item: Item[V]{Value: value, Priority: priority}, seq: q.seq})
+	q.seq++
+}
+
+// PushItem is the same as Push.
+func (q *PQ[V]) PushItem(value V, priority int) {
+	q.Push(value, priority)
+}
+
+// Pop removes the next item and returns its value and priority. ok is
+// false if the queue was empty.
+func (q *PQ[V]) Pop() (value V, priority int, ok bool) {
+	it, ok := q.PopItem()
+	return it.Value, it.Priority, ok
+}
+
+// PopItem is Pop returning the value and priority as an Item.
+func (q *PQ[V]) PopItem() (Item[V], bool) {
+	e, ok := q.h.Pop()
+	return e.Item, ok
+}
+
+// PeekItem returns the item PopItem would remove, without removing it.
+func (q *PQ[V]) PeekItem() (Item[V], bool) {
+	e, ok := q.h.Peek()
+	return e.Item, ok
+}
+
+func (q *PQ[V]) Len() int {
+	return q.h.Len()
+}
@@ -0,0 +1,65 @@
+package goproject
+
+import "testing"
+
+func TestPQItems(t *testing.T) {
+	q := NewPQ[string]()
+	if _, ok := q.PopItem(); ok {
+		t.Fatalf("PopItem on empty queue reported an item")
+	}
+	q.PushItem("clean panels", 1)
+	q.PushItem("replace inverter", 5)
+	q.PushItem("log reading", 1)
+	q.PushItem("recalibrate", 3)
+	q.PushItem("reboot tracker", 5)
+	q.PushItem("archive", 1)
+
+	if it, ok := q.PeekItem(); !ok || it.Value != "replace inverter" || q.Len() != 6 {
+		t.Fatalf("PeekItem = (%+v, %v), Len %d", it, ok, q.Len())
+	}
+	// Equal priorities leave in FIFO order.
+	want := []Item[string]{
+		{"replace inverter", 5},
+		{"reboot tracker", 5},
+		{"recalibrate", 3},
+		{"clean panels", 1},
+		{"log reading", 1},
+		{"archive", 1},
+	}
+	for _, w := range want {
+		if it, ok := q.PopItem(); !ok || it != w {
+			t.Fatalf("PopItem = (%+v, %v), want %+v", it, ok, w)
+		}
+	}
+	if q.Len() != 0 {
+		t.Fatalf("queue not drained")
+	}
+}
+
+func TestPQPushPop(t *testing.T) {
+	q := NewMinPQ[string]()
+	if _, _, ok := q.Pop(); ok {
+		t.Fatalf("Pop on empty queue reported a value")
+	}
+	q.Push("string 3 offline", 2)
+	q.Push("dust alert", 7)
+	q.Push("string 1 offline", 2)
+	q.Push("firmware update", 4)
+	for _, w := range []Item[string]{
+		{"string 3 offline", 2},
+		{"string 1 offline", 2},
+		{"firmware update", 4},
+		{"dust alert", 7},
+	} {
+		if v, p, ok := q.Pop(); !ok || v != w.Value || p != w.Priority {
+			t.Fatalf("Pop = (%q, %d, %v), want %+v", v, p, ok, w)
+		}
+	}
+
+	high := NewPQ[string]()
+	high.Push("low", -1)
+	high.Push("high", 10)
+	if v, p, _ := high.Pop(); v != "high" || p != 10 {
+		t.Fatalf("NewPQ Pop = (%q, %d), want (high, 10)", v, p)
+	}
+}
@@ -0,0 +1,48 @@
+package goproject
+
+// skylineEvent is a building edge at x. h is the negated height for a left
+// edge and the height for a right edge, so ordering events by (x, h) puts
+// left edges before right edges at the same x, taller left edges first and
+// shorter right edges first. That order changes the skyline at most once
+// per x.
+type skylineEvent struct {
+	x, h int
+}
+
+// GetSkyline returns the key points of the skyline formed by buildings,
+// each given as [left, right, height]: the left end of every horizontal
+// segment of the outline, from left to right, with the last point at
+// height 0. Consecutive points never share a height. Building edges come
+// off a min-heap ordered by position, and the live heights are kept in a
+// LazyDeleteHeap so a building is removed in O(1) when its right edge
+// passes and discarded once it surfaces at the top.
+func GetSkyline(buildings [][]int) [][]int {
+	events := NewHeapFunc(func(a, b skylineEvent) bool {
+		if a.x != b.x {
+			return a.x < b.x
+		}
+		return a.h < b.h
+	}, WithCapacity(2*len(buildings)))
+	for _, b := range buildings {
+		events.Push(skylineEvent{b[0], -b[2]}, skylineEvent{b[1], b[2]})
+	}
+	var heights LazyDeleteHeap[int]
+	res := [][]int{}
+	prev := 0
+	for {
+		e, ok := events.Pop()
+		if !ok {
+			return res
+		}
+		if e.h < 0 {
+			heights.Push(-e.h)
+		} else {
+			heights.Delete(e.h)
+		}
+		cur, _ := heights.Peek()
+		if cur != prev {
+			res = append(res, []int{e.x, cur})
+			prev = cur
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package goproject
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestGetSkyline(t *testing.T) {
+	tests := []struct {
+		buildings [][]int
+		want      [][]int
+	}{
+		{
+			[][]int{{2, 9, 10}, {3, 7, 15}, {5, 12, 12}, {15, 20, 10}, {19, 24, 8}},
+			[][]int{{2, 10}, {3, 15}, {7, 12}, {12, 0}, {15, 10}, {20, 8}, {24, 0}},
+		},
+		{[][]int{{0, 2, 3}, {2, 5, 3}}, [][]int{{0, 3}, {5, 0}}},
+		{[][]int{{1, 4, 5}, {2, 3, 5}, {1, 4, 5}}, [][]int{{1, 5}, {4, 0}}},
+		{[][]int{{1, 2, 1}, {1, 2, 2}, {1, 2, 3}}, [][]int{{1, 3}, {2, 0}}},
+		{[][]int{{0, 2, 5}, {2, 4, 3}}, [][]int{{0, 5}, {2, 3}, {4, 0}}},
+		{nil, [][]int{}},
+	}
+	for _, tt := range tests {
+		if got := GetSkyline(tt.buildings); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("GetSkyline(%v) = %v, want %v", tt.buildings, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(47))
+	for iter := 0; iter < 200; iter++ {
+		buildings := make([][]int, r.Intn(8))
+		for i := range buildings {
+			l := r.Intn(20)
+			buildings[i] = []int{l, l + 1 + r.Intn(6), 1 + r.Intn(4)}
+		}
+		got, want := GetSkyline(buildings), bruteSkyline(buildings)
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Fatalf("GetSkyline(%v) = %v, want %v", buildings, got, want)
+		}
+	}
+}
+
+// bruteSkyline samples the outline on every unit interval [x, x+1).
+func bruteSkyline(buildings [][]int) [][]int {
+	res := [][]int{}
+	prev := 0
+	for x := 0; x <= 30; x++ {
+		cur := 0
+		for _, b := range buildings {
+			if b[0] <= x && x < b[1] {
+				cur = max(cur, b[2])
+			}
+		}
+		if cur != prev {
+			res = append(res, []int{x, cur})
+			prev = cur
+		}
+	}
+	return res
+}
@@ -0,0 +1,51 @@
+package goproject
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// StdHeapAdapter exposes a Heap through container/heap's Interface so the
+// standard library's heap.Init, heap.Push, heap.Pop, heap.Fix and
+// heap.Remove can operate on it directly. Both views share the same backing
+// slice, so Heap methods and container/heap calls may be interleaved.
+type StdHeapAdapter[T any] struct {
+	h *Heap[T]
+}
+
+var _ heap.Interface = StdHeapAdapter[int]{}
+
+// NewStdHeapAdapter returns an adapter over h. container/heap only knows
+// the binary layout, so it panics if h was built WithArity other than 2.
+func NewStdHeapAdapter[T any](h *Heap[T]) StdHeapAdapter[T] {
+	if d := h.d(); d != 2 {
+		panic(fmt.Sprintf("goproject: container/heap cannot operate on a %d-ary heap", d))
+	}
+	return StdHeapAdapter[T]{h: h}
+}
+
+func (a StdHeapAdapter[T]) Len() int {
+	return a.h.Len()
+}
+
+func (a StdHeapAdapter[T]) Less(i, j int) bool {
+	return a.h.higher(i, j)
+}
+
+func (a StdHeapAdapter[T]) Swap(i, j int) {
+	a.h.swap(i, j)
+}
+
+// Push appends x without restoring the heap property; container/heap does
+// that. Use heap.Push rather than calling it directly.
+func (a StdHeapAdapter[T]) Push(x any) {
+	a.h.add(x.(T))
+}
+
+// Pop removes the last element; see Push.
+func (a StdHeapAdapter[T]) Pop() any {
+	last := a.h.Len() - 1
+	x := a.h.c[last]
+	a.h.truncate(last)
+	return x
+}
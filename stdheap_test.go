@@ -0,0 +1,90 @@
+package goproject
+
+import (
+	"container/heap"
+	"math/rand"
+	"testing"
+)
+
+func TestStdHeapAdapter(t *testing.T) {
+	h := NewHeapFunc[int](nil)
+	h.c = append(h.c, 3, 8, -1, 4, 8, 0)
+	a := NewStdHeapAdapter(&h)
+	heap.Init(a)
+	heap.Push(a, 10)
+	heap.Push(a, -5)
+
+	var got []int
+	for a.Len() > 0 {
+		got = append(got, heap.Pop(a).(int))
+	}
+	if want := []int{10, 8, 8, 4, 3, 0, -1, -5}; !equalInts(got, want) {
+		t.Fatalf("heap.Pop order = %v, want %v", got, want)
+	}
+
+	minH := NewMinHeap()
+	m := NewStdHeapAdapter(&minH)
+	for _, x := range []int{6, 2, 9, 2} {
+		heap.Push(m, x)
+	}
+	minH.c[0] = 7
+	heap.Fix(m, 0)
+	heap.Remove(m, m.Len()-1)
+	if x, _ := minH.Peek(); x != 2 || minH.Len() != 3 {
+		t.Fatalf("after Fix/Remove: top %d, len %d", x, minH.Len())
+	}
+}
+
+func TestStdHeapAdapterInterleaved(t *testing.T) {
+	r := rand.New(rand.NewSource(15))
+	h := NewHeap()
+	a := NewStdHeapAdapter(&h)
+	counts := make(map[int]int)
+	for op := 0; op < 3000; op++ {
+		x := r.Intn(100) - 50
+		switch r.Intn(5) {
+		case 0:
+			h.Push(x)
+			counts[x]++
+		case 1:
+			heap.Push(a, x)
+			counts[x]++
+		case 2:
+			if got, ok := h.Pop(); ok {
+				counts[got]--
+			}
+		case 3:
+			if a.Len() > 0 {
+				counts[heap.Pop(a).(int)]--
+			}
+		case 4:
+			if a.Len() > 0 {
+				i := r.Intn(a.Len())
+				counts[heap.Remove(a, i).(int)]--
+			}
+		}
+		assertHeap(t, &h)
+		want, nonEmpty := 0, false
+		for v, n := range counts {
+			if n < 0 {
+				t.Fatalf("popped %d more often than it was pushed", v)
+			}
+			if n > 0 && (!nonEmpty || v > want) {
+				want, nonEmpty = v, true
+			}
+		}
+		if got, ok := h.Peek(); ok != nonEmpty || got != want {
+			t.Fatalf("Peek = (%d, %v), want (%d, %v)", got, ok, want, nonEmpty)
+		}
+	}
+}
+
+func TestStdHeapAdapterRejectsArity(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("NewStdHeapAdapter on a 4-ary heap did not panic")
+		}
+	}()
+	h := NewHeap(WithArity(4))
+	NewStdHeapAdapter(&h)
+}
@@ -0,0 +1,45 @@
+package goproject
+
+import "context"
+
+// MaxSlidingWindowStream emits the maximum of the last k values read from
+// in, once for every value from the k-th on, so n inputs yield n-k+1
+// outputs just like MaxSlidingWindow. The returned channel is closed, and
+// the goroutine behind it exits, when in is closed or ctx is cancelled; if
+// fewer than k values ever arrive, or k <= 0, it closes without emitting
+// anything. A value read but not yet delivered when ctx is cancelled is
+// dropped.
+func MaxSlidingWindowStream(ctx context.Context, in <-chan int, k int) <-chan int {
+	out := make(chan int)
+	go func() {
+		defer close(out)
+		if k <= 0 {
+			return
+		}
+		var dq MonoDeque
+		for i := 0; ; i++ {
+			var x int
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-in:
+				if !ok {
+					return
+				}
+				x = v
+			}
+			dq.PushBack(x)
+			dq.EvictBefore(i - k + 1)
+			if i < k-1 {
+				continue
+			}
+			m, _ := dq.Max()
+			select {
+			case <-ctx.Done():
+				return
+			case out <- m:
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,89 @@
+package goproject
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func TestMaxSlidingWindowStream(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for iter := 0; iter < 30; iter++ {
+		nums := make([]int, r.Intn(40))
+		for i := range nums {
+			nums[i] = r.Intn(20) - 10
+		}
+		k := r.Intn(len(nums)+3) - 1
+		in := make(chan int)
+		go func() {
+			defer close(in)
+			for _, x := range nums {
+				in <- x
+			}
+		}()
+		var got []int
+		for m := range MaxSlidingWindowStream(context.Background(), in, k) {
+			got = append(got, m)
+		}
+		if want := MaxSlidingWindow(nums, k); !equalInts(got, want) {
+			t.Fatalf("stream of %v, k=%d emitted %v, want %v", nums, k, got, want)
+		}
+	}
+}
+
+func TestMaxSlidingWindowStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int)
+	out := MaxSlidingWindowStream(ctx, in, 3)
+	for _, x := range []int{5, 1, 4} {
+		in <- x
+	}
+	if m := <-out; m != 5 {
+		t.Fatalf("first window max = %d, want 5", m)
+	}
+	in <- 2
+	cancel()
+	// The pending max may or may not be delivered, but out must close even
+	// though in stays open and nobody is sending.
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case _, ok := <-out:
+			if !ok {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("output channel not closed after cancel")
+		}
+	}
+}
+
+func TestMaxSlidingWindowStreamCancelBlockedSend(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	in := make(chan int, 4)
+	for _, x := range []int{3, 9, 2, 7} {
+		in <- x
+	}
+	out := MaxSlidingWindowStream(ctx, in, 1)
+	<-out
+	// Nobody reads out any more; cancelling must still release the
+	// goroutine blocked on sending.
+	cancel()
+	select {
+	case <-drained(out):
+	case <-time.After(5 * time.Second):
+		t.Fatalf("output channel not closed after cancel")
+	}
+}
+
+// drained reads c until it is closed and then closes the returned channel.
+func drained(c <-chan int) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		for range c {
+		}
+		close(done)
+	}()
+	return done
+}
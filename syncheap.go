@@ -0,0 +1,51 @@
+package goproject
+
+import "sync"
+
+// SyncHeap is a Heap that is safe for concurrent use. Each method holds the
+// lock only for the duration of the underlying Heap operation.
+type SyncHeap[T any] struct {
+	mu sync.Mutex
+	h  Heap[T]
+}
+
+// NewSyncHeap wraps h. The caller must not keep using h directly.
+func NewSyncHeap[T any](h Heap[T]) *SyncHeap[T] {
+	return &SyncHeap[T]{h: h}
+}
+
+func (s *SyncHeap[T]) Push(xs ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.h.Push(xs...)
+}
+
+func (s *SyncHeap[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Pop()
+}
+
+func (s *SyncHeap[T]) Peek() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Peek()
+}
+
+func (s *SyncHeap[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Len()
+}
+
+func (s *SyncHeap[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.Size()
+}
+
+func (s *SyncHeap[T]) IsEmpty() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.h.IsEmpty()
+}
@@ -0,0 +1,87 @@
+package goproject
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestSyncHeapProducers(t *testing.T) {
+	const producers, perProducer = 8, 500
+	s := NewSyncHeap(NewHeap())
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				s.Push(p*perProducer + i)
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	done := make(chan []int)
+	go func() {
+		var got []int
+		for !s.IsEmpty() {
+			x, _ := s.Pop()
+			got = append(got, x)
+		}
+		done <- got
+	}()
+	got := <-done
+	if len(got) != producers*perProducer {
+		t.Fatalf("consumer got %d items, want %d", len(got), producers*perProducer)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i] > got[i-1] {
+			t.Fatalf("consumer saw %d after %d", got[i], got[i-1])
+		}
+	}
+	if s.Size() != 0 {
+		t.Fatalf("Size = %d after draining", s.Size())
+	}
+}
+
+func TestSyncHeapConcurrentConsumer(t *testing.T) {
+	const producers, perProducer = 4, 1000
+	s := NewSyncHeap(NewMinHeap())
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				s.Push(p*perProducer + i)
+			}
+		}(p)
+	}
+	produced := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(produced)
+	}()
+
+	seen := make(map[int]bool)
+	for finished := false; ; {
+		select {
+		case <-produced:
+			finished = true
+		default:
+		}
+		x, ok := s.Pop()
+		if !ok {
+			if finished && s.Len() == 0 {
+				break
+			}
+			continue
+		}
+		if x < 0 || x >= producers*perProducer || seen[x] {
+			t.Fatalf("popped %d, which was never pushed or was popped twice", x)
+		}
+		seen[x] = true
+	}
+	if len(seen) != producers*perProducer {
+		t.Fatalf("popped %d distinct values, want %d", len(seen), producers*perProducer)
+	}
+}
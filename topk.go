@@ -0,0 +1,135 @@
+package goproject
+
+// BoundedHeap keeps at most k elements: the k that rank highest under its
+// ordering, i.e. the k a plain Heap with the same ordering would pop first.
+// Internally the weakest kept element sits on top so it can be evicted in
+// O(log k).
+type BoundedHeap[T any] struct {
+	h    Heap[T]
+	k    int
+	less func(a, b T) bool
+}
+
+// boundedInitialCap caps the capacity a BoundedHeap preallocates.
+const boundedInitialCap = 64
+
+// NewBoundedHeap returns a bounded heap keeping the k largest ints pushed.
+// A k <= 0 keeps nothing.
+func NewBoundedHeap(k int) BoundedHeap[int] {
+	return NewBoundedHeapFunc(k, greater[int])
+}
+
+// NewBoundedHeapFunc returns a bounded heap keeping the k elements that
+// rank highest under less, which has the same meaning as for NewHeapFunc.
+// The heap starts small and grows to k as elements arrive, so a k as large
+// as math.MaxInt keeps everything.
+func NewBoundedHeapFunc[T any](k int, less func(a, b T) bool) BoundedHeap[T] {
+	k = max(k, 0)
+	weakestFirst := func(a, b T) bool { return less(b, a) }
+	return BoundedHeap[T]{h: newHeap(min(k, boundedInitialCap), weakestFirst), k: k, less: less}
+}
+
+// Push offers x to the heap. If that leaves more than k elements, the
+// weakest one (possibly x itself) is dropped and returned with ok true.
+func (b *BoundedHeap[T]) Push(x T) (evicted T, ok bool) {
+	if b.h.Len() < b.k {
+		b.h.Push(x)
+		return evicted, false
+	}
+	top, full := b.h.Peek()
+	if !full || !b.less(x, top) {
+		return x, true
+	}
+	b.h.Replace(x)
+	return top, true
+}
+
+func (b *BoundedHeap[T]) Len() int {
+	return b.h.Len()
+}
+
+// Limit returns the k the heap was built with.
+func (b *BoundedHeap[T]) Limit() int {
+	return b.k
+}
+
+// Values returns the kept elements, highest ranked first, without
+// modifying the heap.
+func (b *BoundedHeap[T]) Values() []T {
+	res := b.h.Sorted()
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// TopK returns the k largest values of nums in descending order. It returns
+// an empty slice for k <= 0 and all of nums, sorted, when k >= len(nums).
+func TopK(nums []int, k int) []int {
+	b := NewBoundedHeap(min(k, len(nums)))
+	for _, x := range nums {
+		b.Push(x)
+	}
+	return b.Values()
+}
+
+// KthLargest returns the k-th largest value of nums, counting duplicates
+// individually, so k = 1 is the maximum and k = len(nums) the minimum. It
+// keeps a BoundedHeap of the k largest, whose weakest element is the answer.
+// ok is false if k is not in [1, len(nums)].
+func KthLargest(nums []int, k int) (int, bool) {
+	if k < 1 || k > len(nums) {
+		return 0, false
+	}
+	b := NewBoundedHeap(k)
+	for _, x := range nums {
+		b.Push(x)
+	}
+	return b.h.Peek()
+}
+
+// TopKFrequent returns the k most frequent values of nums, most frequent
+// first. Values seen equally often are ordered smallest first, so the result
+// is deterministic. It returns every distinct value when k exceeds their
+// number and an empty slice for k <= 0.
+func TopKFrequent(nums []int, k int) []int {
+	type valueCount struct{ value, count int }
+	counts := make(map[int]int)
+	for _, x := range nums {
+		counts[x]++
+	}
+	b := NewBoundedHeapFunc(min(k, len(counts)), func(a, b valueCount) bool {
+		return a.count > b.count || a.count == b.count && a.value < b.value
+	})
+	for v, n := range counts {
+		b.Push(valueCount{v, n})
+	}
+	res := make([]int, 0, b.Len())
+	for _, vc := range b.Values() {
+		res = append(res, vc.value)
+	}
+	return res
+}
+
+// TopKTracker keeps the k largest ints seen in a stream. It is the int
+// BoundedHeap behind a stream-oriented API; the name avoids clashing with
+// the TopK function.
+type TopKTracker struct {
+	b BoundedHeap[int]
+}
+
+// NewTopKTracker returns a tracker for the k largest values.
+func NewTopKTracker(k int) *TopKTracker {
+	return &TopKTracker{b: NewBoundedHeap(k)}
+}
+
+// Add records x, evicting the current smallest kept value if the tracker is
+// full and x is larger.
+func (t *TopKTracker) Add(x int) {
+	t.b.Push(x)
+}
+
+// Values returns the current top k in descending order.
+func (t *TopKTracker) Values() []int {
+	return t.b.Values()
+}
@@ -0,0 +1,203 @@
+package goproject
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestTopK(t *testing.T) {
+	tests := []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		{[]int{5, 1, 5, 3, 5, 2}, 2, []int{5, 5}},
+		{[]int{4, 7, 7, 1, 7, 0}, 3, []int{7, 7, 7}},
+		{[]int{4, 6, 6, 1, 6, 2}, 2, []int{6, 6}},
+		{[]int{3, 9, 9, 1, 2}, 1, []int{9}},
+		{[]int{3, 1, 2}, 3, []int{3, 2, 1}},
+		{[]int{3, 1, 2}, 10, []int{3, 2, 1}},
+		{[]int{3, 1, 2}, 0, []int{}},
+		{[]int{3, 1, 2}, -2, []int{}},
+		{nil, 3, []int{}},
+	}
+	for _, tt := range tests {
+		got := TopK(tt.nums, tt.k)
+		if got == nil || !equalInts(got, tt.want) {
+			t.Errorf("TopK(%v, %d) = %#v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(12))
+	for iter := 0; iter < 50; iter++ {
+		nums := make([]int, r.Intn(80))
+		for i := range nums {
+			nums[i] = r.Intn(20)
+		}
+		k := r.Intn(len(nums) + 2)
+		if got, want := TopK(nums, k), sortTopK(nums, k); !equalInts(got, want) {
+			t.Fatalf("TopK(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+}
+
+func TestBoundedHeapEvicts(t *testing.T) {
+	b := NewBoundedHeap(2)
+	if _, ok := b.Push(4); ok {
+		t.Fatalf("evicted while below the limit")
+	}
+	b.Push(1)
+	if x, ok := b.Push(3); !ok || x != 1 {
+		t.Fatalf("Push(3) evicted (%d, %v), want (1, true)", x, ok)
+	}
+	if x, ok := b.Push(0); !ok || x != 0 {
+		t.Fatalf("Push(0) evicted (%d, %v), want the new value back", x, ok)
+	}
+	if got := b.Values(); !equalInts(got, []int{4, 3}) || b.Len() != 2 {
+		t.Fatalf("Values = %v, Len %d", got, b.Len())
+	}
+
+	smallest := NewBoundedHeapFunc(2, func(a, b string) bool { return a < b })
+	for _, s := range []string{"m", "c", "x", "a", "c"} {
+		smallest.Push(s)
+	}
+	if got := smallest.Values(); len(got) != 2 || got[0] != "a" || got[1] != "c" {
+		t.Fatalf("two smallest = %q", got)
+	}
+
+	none := NewBoundedHeap(-1)
+	if x, ok := none.Push(5); !ok || x != 5 || none.Len() != 0 {
+		t.Fatalf("k=-1 kept %d elements, Push returned (%d, %v)", none.Len(), x, ok)
+	}
+
+	all := NewBoundedHeap(math.MaxInt)
+	if c := cap(all.h.c); c > boundedInitialCap {
+		t.Fatalf("NewBoundedHeap(MaxInt) preallocated %d slots", c)
+	}
+	for i := 0; i < 1000; i++ {
+		if _, ok := all.Push(i); ok {
+			t.Fatalf("k=MaxInt evicted at Push(%d)", i)
+		}
+	}
+	if all.Len() != 1000 {
+		t.Fatalf("k=MaxInt kept %d of 1000", all.Len())
+	}
+}
+
+func TestTopKTracker(t *testing.T) {
+	r := rand.New(rand.NewSource(20))
+	for _, k := range []int{0, 1, 10, 999, 1000, 1500} {
+		tr := NewTopKTracker(k)
+		var seen []int
+		for i := 0; i < 1000; i++ {
+			x := r.Intn(500) - 250
+			tr.Add(x)
+			seen = append(seen, x)
+			if i%97 == 0 {
+				if got, want := tr.Values(), sortTopK(seen, k); !equalInts(got, want) {
+					t.Fatalf("k=%d after %d values: %v, want %v", k, i+1, got, want)
+				}
+			}
+		}
+		if got, want := tr.Values(), sortTopK(seen, k); !equalInts(got, want) {
+			t.Fatalf("k=%d: Values = %v, want %v", k, got, want)
+		}
+	}
+}
+
+func TestKthLargest(t *testing.T) {
+	nums := []int{3, 2, 3, 1, 2, 4, 5, 5, 6}
+	tests := []struct {
+		k    int
+		want int
+		ok   bool
+	}{
+		{1, 6, true},
+		{2, 5, true},
+		{3, 5, true},
+		{4, 4, true},
+		{5, 3, true},
+		{6, 3, true},
+		{len(nums), 1, true},
+		{len(nums) + 1, 0, false},
+		{0, 0, false},
+		{-1, 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := KthLargest(nums, tt.k); got != tt.want || ok != tt.ok {
+			t.Errorf("KthLargest(%v, %d) = (%d, %v), want (%d, %v)", nums, tt.k, got, ok, tt.want, tt.ok)
+		}
+	}
+	if _, ok := KthLargest(nil, 1); ok {
+		t.Errorf("KthLargest(nil, 1) reported ok")
+	}
+}
+
+func TestTopKFrequent(t *testing.T) {
+	tests := []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		{[]int{1, 1, 1, 2, 2, 3}, 2, []int{1, 2}},
+		{[]int{4, 4, 2, 2, 9}, 1, []int{2}},
+		{[]int{4, 4, 2, 2, 9}, 10, []int{2, 4, 9}},
+		{[]int{5, 3, 8, 1}, 2, []int{1, 3}},
+		{[]int{5, 3, 8, 1}, 4, []int{1, 3, 5, 8}},
+		{[]int{7, 7}, 0, []int{}},
+		{nil, 2, []int{}},
+	}
+	for _, tt := range tests {
+		got := TopKFrequent(tt.nums, tt.k)
+		if got == nil || !equalInts(got, tt.want) {
+			t.Errorf("TopKFrequent(%v, %d) = %#v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(30))
+	for iter := 0; iter < 50; iter++ {
+		nums := make([]int, r.Intn(100))
+		for i := range nums {
+			nums[i] = r.Intn(15)
+		}
+		counts := map[int]int{}
+		for _, x := range nums {
+			counts[x]++
+		}
+		var want []int
+		for v := range counts {
+			want = append(want, v)
+		}
+		sort.Slice(want, func(i, j int) bool {
+			a, b := want[i], want[j]
+			return counts[a] > counts[b] || counts[a] == counts[b] && a < b
+		})
+		k := r.Intn(len(want) + 3)
+		if got := TopKFrequent(nums, k); !equalInts(got, want[:min(k, len(want))]) {
+			t.Fatalf("TopKFrequent(%v, %d) = %v, want %v", nums, k, got, want[:min(k, len(want))])
+		}
+	}
+}
+
+func sortTopK(nums []int, k int) []int {
+	s := append([]int(nil), nums...)
+	sort.Sort(sort.Reverse(sort.IntSlice(s)))
+	return s[:max(0, min(k, len(s)))]
+}
+
+func BenchmarkTopK(b *testing.B) {
+	nums := rand.New(rand.NewSource(13)).Perm(100000)
+	const k = 10
+	b.Run("bounded-heap", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			TopK(nums, k)
+		}
+	})
+	b.Run("sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sortTopK(nums, k)
+		}
+	})
+}
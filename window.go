@@ -0,0 +1,478 @@
+package goproject
+
+import (
+	"cmp"
+	"errors"
+	"math"
+)
+
+// ErrNaN is returned by the floating-point window functions when their
+// input contains a NaN, which has no place in a max ordering.
+var ErrNaN = errors.New("goproject: NaN in window input")
+
+// ErrStride is returned by MaxSlidingWindowStride for a stride below 1.
+var ErrStride = errors.New("goproject: window stride must be positive")
+
+// MaxSlidingWindowFloat returns the maximum of every window of k
+// consecutive readings. ±Inf are ordinary values; a NaN anywhere in nums
+// makes it return ErrNaN rather than a silently wrong answer. As for
+// MaxSlidingWindow, a k outside [1, len(nums)] yields an empty slice.
+func MaxSlidingWindowFloat(nums []float64, k int) ([]float64, error) {
+	for _, x := range nums {
+		if math.IsNaN(x) {
+			return nil, ErrNaN
+		}
+	}
+	return MaxSlidingWindow(nums, k), nil
+}
+
+// maxSlidingWindowDeque computes the same maxima as maxSlidingWindow in O(n)
+// time and O(k) space on a MonoDeque, against O(n log k) for the heap.
+func maxSlidingWindowDeque(nums []int, k int) []int {
+	if k <= 0 || k > len(nums) {
+		return []int{}
+	}
+	res := make([]int, 0, len(nums)-k+1)
+	var dq MonoDeque
+	for i, x := range nums {
+		dq.PushBack(x)
+		dq.EvictBefore(i - k + 1)
+		if i >= k-1 {
+			m, _ := dq.Max()
+			res = append(res, m)
+		}
+	}
+	return res
+}
+
+// MinSlidingWindow returns the minimum of every window of k consecutive
+// elements of nums, with the same contract as MaxSlidingWindow: an empty
+// slice when k <= 0 or k > len(nums). It runs on the same lazily-deleting
+// heap, ordered the other way.
+func MinSlidingWindow(nums []int, k int) []int {
+	return slidingWindowTop(nums, k, WithMinOrdering())
+}
+
+// minSlidingWindowDeque computes the same minima as MinSlidingWindow in
+// O(n). It mirrors maxSlidingWindowDeque with the invariant flipped: the
+// deque's values are strictly increasing from front to back.
+func minSlidingWindowDeque(nums []int, k int) []int {
+	if k <= 0 || k > len(nums) {
+		return []int{}
+	}
+	res := make([]int, 0, len(nums)-k+1)
+	var dq Deque[int]
+	for i := range nums {
+		slideIndex(&dq, nums, i, k, cmp.Less[int])
+		if i >= k-1 {
+			front, _ := dq.Front()
+			res = append(res, nums[front])
+		}
+	}
+	return res
+}
+
+// slideIndex advances dq, a deque of indices into nums for a monotonic
+// window of k, to the window ending at i: the front index is dropped once
+// it falls out of the window, and indices whose values no longer rank
+// strictly before nums[i] under before are dropped from the back before i
+// is appended. The front then indexes the window's top value.
+func slideIndex(dq *Deque[int], nums []int, i, k int, before func(a, b int) bool) {
+	if front, ok := dq.Front(); ok && front <= i-k {
+		dq.PopFront()
+	}
+	for back, ok := dq.Back(); ok && !before(nums[back], nums[i]); back, ok = dq.Back() {
+		dq.PopBack()
+	}
+	dq.PushBack(i)
+}
+
+// SlidingWindowSum returns the sum of every window of k consecutive
+// elements of nums in O(n), keeping a running sum rather than re-adding each
+// window. It returns an empty slice when k <= 0 or k > len(nums). The
+// running sum wraps on overflow like any int arithmetic, but since
+// wrapping is undone by the later subtractions, each window's sum is exact
+// whenever it fits in an int itself.
+func SlidingWindowSum(nums []int, k int) []int {
+	if k <= 0 || k > len(nums) {
+		return []int{}
+	}
+	res := make([]int, 0, len(nums)-k+1)
+	sum := 0
+	for i, x := range nums {
+		sum += x
+		if i >= k {
+			sum -= nums[i-k]
+		}
+		if i >= k-1 {
+			res = append(res, sum)
+		}
+	}
+	return res
+}
+
+// SlidingWindowDistinct returns the number of distinct values in every
+// window of k consecutive elements, with the same contract for k as
+// SlidingWindowSum. A frequency map of the window is updated as elements
+// enter and leave, and values whose count drops to zero are removed, so
+// the pass is O(n).
+func SlidingWindowDistinct(nums []int, k int) []int {
+	if k <= 0 || k > len(nums) {
+		return []int{}
+	}
+	res := make([]int, 0, len(nums)-k+1)
+	freq := make(map[int]int, k)
+	for i, x := range nums {
+		freq[x]++
+		if i >= k {
+			old := nums[i-k]
+			if freq[old]--; freq[old] == 0 {
+				delete(freq, old)
+			}
+		}
+		if i >= k-1 {
+			res = append(res, len(freq))
+		}
+	}
+	return res
+}
+
+// modeEntry records that value occurred count times in the window when it
+// was pushed. It is stale once the count has changed.
+type modeEntry struct {
+	count, value int
+}
+
+// windowMode tracks the mode of a window: the frequency of every value in
+// it, and a heap of modeEntry ordered by count and then value whose top,
+// once stale entries are popped, is the mode.
+type windowMode struct {
+	freq map[int]int
+	h    Heap[modeEntry]
+}
+
+func newWindowMode(k int) *windowMode {
+	return &windowMode{
+		freq: make(map[int]int, k),
+		h: NewHeapFunc(func(a, b modeEntry) bool {
+			if a.count != b.count {
+				return a.count > b.count
+			}
+			return a.value < b.value
+		}, WithCapacity(2*k+2)),
+	}
+}
+
+// change adds d to the count of x and records the new count in the heap.
+func (m *windowMode) change(x, d int) {
+	n := m.freq[x] + d
+	if n == 0 {
+		delete(m.freq, x)
+	} else {
+		m.freq[x] = n
+		m.h.Push(modeEntry{n, x})
+	}
+	m.sweep()
+}
+
+// sweep rebuilds the heap from its live entries, one per value, once the
+// stale ones outnumber them. A stale entry ranked below the top would
+// otherwise stay forever, e.g. every expired value of a decreasing stream.
+// Each sweep costs O(k) and follows O(k) changes.
+func (m *windowMode) sweep() {
+	if m.h.Len() <= 2*len(m.freq) {
+		return
+	}
+	kept := make(map[int]bool, len(m.freq))
+	m.h.retain(func(e modeEntry) bool {
+		if kept[e.value] || m.freq[e.value] != e.count {
+			return false
+		}
+		kept[e.value] = true
+		return true
+	})
+}
+
+// mode returns the most frequent value, the smallest on a tie. The window
+// must not be empty.
+func (m *windowMode) mode() int {
+	for {
+		top, _ := m.h.Peek()
+		if m.freq[top.value] == top.count {
+			return top.value
+		}
+		m.h.Pop()
+	}
+}
+
+// SlidingWindowMode returns the most frequent value in every window of k
+// consecutive elements, the smallest of them when several share the top
+// frequency, with the same contract for k as SlidingWindowSum. Each change
+// to a frequency pushes a fresh entry onto a heap ordered by count and then
+// value; stale entries are discarded when they reach the top or swept out
+// once they outnumber the live ones, so the heap holds O(k) entries and
+// the pass is O(n log k).
+func SlidingWindowMode(nums []int, k int) []int {
+	if k <= 0 || k > len(nums) {
+		return []int{}
+	}
+	res := make([]int, 0, len(nums)-k+1)
+	m := newWindowMode(k)
+	for i, x := range nums {
+		m.change(x, 1)
+		if i >= k {
+			m.change(nums[i-k], -1)
+		}
+		if i >= k-1 {
+			res = append(res, m.mode())
+		}
+	}
+	return res
+}
+
+// MovingAverage returns the mean of every window of k consecutive readings
+// in O(n), with the same contract for k as SlidingWindowSum. The running
+// sum of finite readings is Neumaier-compensated, so the digits a large
+// reading rounds away from the small ones beside it are kept and come back
+// once it leaves. Infinities and NaNs are counted apart from the sum, so
+// they only affect the windows that contain them: such a window averages
+// to ±Inf, or NaN if it holds a NaN or both infinities.
+func MovingAverage(nums []float64, k int) []float64 {
+	if k <= 0 || k > len(nums) {
+		return []float64{}
+	}
+	res := make([]float64, 0, len(nums)-k+1)
+	var sum, comp float64 // the window's sum is sum + comp
+	var nan, posInf, negInf int
+	count := func(x float64, d int) {
+		switch {
+		case math.IsNaN(x):
+			nan += d
+		case math.IsInf(x, 1):
+			posInf += d
+		case math.IsInf(x, -1):
+			negInf += d
+		default:
+			x *= float64(d)
+			t := sum + x
+			if math.Abs(sum) >= math.Abs(x) {
+				comp += (sum - t) + x
+			} else {
+				comp += (x - t) + sum
+			}
+			sum = t
+		}
+	}
+	for i, x := range nums {
+		count(x, 1)
+		if i >= k {
+			count(nums[i-k], -1)
+		}
+		if i < k-1 {
+			continue
+		}
+		switch {
+		case nan > 0 || posInf > 0 && negInf > 0:
+			res = append(res, math.NaN())
+		case posInf > 0:
+			res = append(res, math.Inf(1))
+		case negInf > 0:
+			res = append(res, math.Inf(-1))
+		default:
+			res = append(res, (sum+comp)/float64(k))
+		}
+	}
+	return res
+}
+
+// moments holds the count, mean and sum of squared deviations of a run of
+// readings. The mean is kept relative to shift, the first reading added,
+// so that readings sharing a large offset lose no digits to it.
+type moments struct {
+	n               int
+	shift, mean, m2 float64
+}
+
+// add returns m with x appended, by Welford's update.
+func (m moments) add(x float64) moments {
+	if m.n == 0 {
+		return moments{n: 1, shift: x}
+	}
+	m.n++
+	d := x - m.shift - m.mean
+	m.mean += d / float64(m.n)
+	m.m2 += d * (x - m.shift - m.mean)
+	return m
+}
+
+// merge returns the moments of the readings of m and o together. Every
+// term it adds to m2 is non-negative, so nothing cancels.
+func (m moments) merge(o moments) moments {
+	switch {
+	case o.n == 0:
+		return m
+	case m.n == 0:
+		return o
+	}
+	n := m.n + o.n
+	d := (o.shift - m.shift) + (o.mean - m.mean)
+	return moments{
+		n:     n,
+		shift: m.shift,
+		mean:  m.mean + d*float64(o.n)/float64(n),
+		m2:    m.m2 + o.m2 + d*d*float64(m.n)*float64(o.n)/float64(n),
+	}
+}
+
+// SlidingWindowVariance returns the population variance, the mean squared
+// deviation dividing by k rather than k-1, of every window of k consecutive
+// readings in O(n), with the same contract for k as SlidingWindowSum; a
+// window of one reading has variance 0. nums is cut into blocks of k, so
+// each window is a suffix of one block followed by a prefix of the next.
+// The suffix moments of a block are built once from the right, the prefix
+// moments grow with the window, and each window merges the two. No reading
+// is ever subtracted back out of a running total, so neither a large
+// offset nor a change of level, such as night readings after daytime ones,
+// costs precision in later windows. Readings must be finite.
+func SlidingWindowVariance(nums []float64, k int) []float64 {
+	if k <= 0 || k > len(nums) {
+		return []float64{}
+	}
+	res := make([]float64, 0, len(nums)-k+1)
+	suffix := make([]moments, k)
+	var prefix moments
+	for start := 0; start+k <= len(nums); start++ {
+		j := start % k
+		if j == 0 {
+			// The window is exactly the block at start; the next block's
+			// prefix starts empty.
+			var m moments
+			for i := k - 1; i >= 0; i-- {
+				m = m.add(nums[start+i])
+				suffix[i] = m
+			}
+			prefix = moments{}
+		} else {
+			prefix = prefix.add(nums[start+k-1])
+		}
+		res = append(res, suffix[j].merge(prefix).m2/float64(k))
+	}
+	return res
+}
+
+// SlidingWindowStdDev returns the square root of each window's
+// SlidingWindowVariance, the population standard deviation.
+func SlidingWindowStdDev(nums []float64, k int) []float64 {
+	res := SlidingWindowVariance(nums, k)
+	for i, v := range res {
+		res[i] = math.Sqrt(v)
+	}
+	return res
+}
+
+// WindowStat summarises one window of WindowStats.
+type WindowStat struct {
+	Min, Max int
+	Mean     float64
+}
+
+// WindowStats returns the minimum, maximum and mean of every window of k
+// consecutive elements of nums in a single O(n) pass, using the two
+// monotonic deques of minSlidingWindowDeque and maxSlidingWindowDeque and
+// the running sum of SlidingWindowSum. It returns an empty slice when
+// k <= 0 or k > len(nums).
+func WindowStats(nums []int, k int) []WindowStat {
+	if k <= 0 || k > len(nums) {
+		return []WindowStat{}
+	}
+	res := make([]WindowStat, 0, len(nums)-k+1)
+	var lo, hi Deque[int]
+	sum := 0
+	for i, x := range nums {
+		slideIndex(&lo, nums, i, k, cmp.Less[int])
+		slideIndex(&hi, nums, i, k, greater[int])
+		sum += x
+		if i >= k {
+			sum -= nums[i-k]
+		}
+		if i >= k-1 {
+			lowest, _ := lo.Front()
+			highest, _ := hi.Front()
+			res = append(res, WindowStat{Min: nums[lowest], Max: nums[highest], Mean: float64(sum) / float64(k)})
+		}
+	}
+	return res
+}
+
+// MaxSlidingWindowWithIndex is maxSlidingWindow that also reports where
+// each maximum sits: idxs[i] is the index into nums of maxes[i]. When a
+// window holds its maximum more than once, the smallest index is reported.
+// Both slices are empty when k <= 0 or k > len(nums).
+func MaxSlidingWindowWithIndex(nums []int, k int) (maxes, idxs []int) {
+	if k <= 0 || k > len(nums) {
+		return []int{}, []int{}
+	}
+	maxes = make([]int, 0, len(nums)-k+1)
+	idxs = make([]int, 0, len(nums)-k+1)
+	// Unlike MonoDeque, equal values are kept, so the front is the oldest
+	// copy of the maximum.
+	var dq Deque[int]
+	for i := range nums {
+		slideIndex(&dq, nums, i, k, func(a, b int) bool { return a >= b })
+		if i >= k-1 {
+			front, _ := dq.Front()
+			maxes = append(maxes, nums[front])
+			idxs = append(idxs, front)
+		}
+	}
+	return maxes, idxs
+}
+
+// MaxSlidingWindowStride returns the maximum of the windows of k
+// consecutive elements starting at indices 0, stride, 2*stride, and so on,
+// in O(n) whatever the stride. Only full windows are reported, so trailing
+// elements that do not complete another window are ignored. A stride of 1
+// gives exactly maxSlidingWindow; a stride below 1 returns ErrStride. As
+// there, k outside [1, len(nums)] yields an empty slice.
+func MaxSlidingWindowStride(nums []int, k, stride int) ([]int, error) {
+	if stride < 1 {
+		return nil, ErrStride
+	}
+	if k <= 0 || k > len(nums) {
+		return []int{}, nil
+	}
+	res := make([]int, 0, (len(nums)-k)/stride+1)
+	var dq MonoDeque
+	for i, x := range nums {
+		dq.PushBack(x)
+		start := i - k + 1
+		dq.EvictBefore(start)
+		if start >= 0 && start%stride == 0 {
+			m, _ := dq.Max()
+			res = append(res, m)
+		}
+	}
+	return res, nil
+}
+
+// SlidingWindowRange returns max - min of every window of k consecutive
+// elements of nums, 0 for a window of equal values, with the same contract
+// for k as SlidingWindowSum. Both monotonic deques advance in the same
+// O(n) pass. A range that overflows an int wraps.
+func SlidingWindowRange(nums []int, k int) []int {
+	if k <= 0 || k > len(nums) {
+		return []int{}
+	}
+	res := make([]int, 0, len(nums)-k+1)
+	var lo, hi Deque[int]
+	for i := range nums {
+		slideIndex(&lo, nums, i, k, cmp.Less[int])
+		slideIndex(&hi, nums, i, k, greater[int])
+		if i >= k-1 {
+			lowest, _ := lo.Front()
+			highest, _ := hi.Front()
+			res = append(res, nums[highest]-nums[lowest])
+		}
+	}
+	return res
+}
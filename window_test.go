@@ -0,0 +1,705 @@
+package goproject
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMaxSlidingWindowDeque(t *testing.T) {
+	r := rand.New(rand.NewSource(25))
+	for iter := 0; iter < 300; iter++ {
+		nums := make([]int, 1+r.Intn(50))
+		for i := range nums {
+			nums[i] = r.Intn(12) - 6
+		}
+		k := 1 + r.Intn(len(nums))
+		if got, want := maxSlidingWindowDeque(nums, k), bruteMaxWindow(nums, k); !equalInts(got, want) {
+			t.Fatalf("maxSlidingWindowDeque(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+	if got := maxSlidingWindowDeque([]int{9, 10, 9, -7, -4, 8, 2, -6}, 5); !equalInts(got, []int{10, 10, 9, 8}) {
+		t.Fatalf("example = %v", got)
+	}
+}
+
+func TestMinSlidingWindow(t *testing.T) {
+	r := rand.New(rand.NewSource(27))
+	for iter := 0; iter < 300; iter++ {
+		nums := make([]int, 1+r.Intn(50))
+		for i := range nums {
+			nums[i] = r.Intn(12) - 6
+		}
+		k := 1 + r.Intn(len(nums))
+		want := bruteMinWindow(nums, k)
+		if got := MinSlidingWindow(nums, k); !equalInts(got, want) {
+			t.Fatalf("MinSlidingWindow(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+		if got := minSlidingWindowDeque(nums, k); !equalInts(got, want) {
+			t.Fatalf("minSlidingWindowDeque(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+	if got := MinSlidingWindow([]int{1, 3, -1, -3, 5, 3, 6, 7}, 3); !equalInts(got, []int{-1, -3, -3, -3, 3, 3}) {
+		t.Fatalf("example = %v", got)
+	}
+
+	// A window of equal values has the same minimum and maximum.
+	nums := []int{4, 4, 4, -2, 7, 7, 7}
+	mins, maxs := MinSlidingWindow(nums, 3), maxSlidingWindow(nums, 3)
+	for _, i := range []int{0, 4} {
+		if mins[i] != nums[i] || maxs[i] != nums[i] {
+			t.Fatalf("window %d of %v: min %d, max %d, want both %d", i, nums, mins[i], maxs[i], nums[i])
+		}
+	}
+}
+
+func bruteMinWindow(nums []int, k int) []int {
+	var res []int
+	for i := 0; i+k <= len(nums); i++ {
+		m := nums[i]
+		for _, x := range nums[i : i+k] {
+			if x < m {
+				m = x
+			}
+		}
+		res = append(res, m)
+	}
+	return res
+}
+
+func TestMaxSlidingWindowGeneric(t *testing.T) {
+	// Truncating to ints would tie 1.9 and 1.2.
+	floats := MaxSlidingWindow([]float64{1.2, 1.9, 0.5, 1.25, -3}, 2)
+	for i, want := range []float64{1.9, 1.9, 1.25, 1.25} {
+		if floats[i] != want {
+			t.Fatalf("float64 windows = %v, window %d want %v", floats, i, want)
+		}
+	}
+	wide := MaxSlidingWindow([]int64{1 << 40, -1, 1<<40 + 1, 7}, 3)
+	if len(wide) != 2 || wide[0] != 1<<40+1 || wide[1] != 1<<40+1 {
+		t.Fatalf("int64 windows = %v", wide)
+	}
+	words := MaxSlidingWindow([]string{"ash", "oak", "elm", "fir", "birch"}, 2)
+	for i, want := range []string{"oak", "oak", "fir", "fir"} {
+		if words[i] != want {
+			t.Fatalf("string windows = %q, window %d want %q", words, i, want)
+		}
+	}
+	if got := MaxSlidingWindow([]string{"a"}, 2); got == nil || len(got) != 0 {
+		t.Fatalf("MaxSlidingWindow with k > len = %#v, want empty", got)
+	}
+}
+
+func TestSlidingWindowInvalidK(t *testing.T) {
+	nums := []int{4, 2, 12, 3}
+	tests := []struct {
+		name     string
+		nums     []int
+		k        int
+		max, min []int
+	}{
+		{"k zero", nums, 0, []int{}, []int{}},
+		{"k negative", nums, -2, []int{}, []int{}},
+		{"k too large", nums, 5, []int{}, []int{}},
+		{"empty input", []int{}, 1, []int{}, []int{}},
+		{"nil input", nil, 3, []int{}, []int{}},
+		{"k equals len", nums, 4, []int{12}, []int{2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, fn := range []struct {
+				name string
+				f    func([]int, int) []int
+				want []int
+			}{
+				{"MaxSlidingWindow", MaxSlidingWindow[int], tt.max},
+				{"maxSlidingWindow", maxSlidingWindow, tt.max},
+				{"maxSlidingWindowDeque", maxSlidingWindowDeque, tt.max},
+				{"MinSlidingWindow", MinSlidingWindow, tt.min},
+				{"minSlidingWindowDeque", minSlidingWindowDeque, tt.min},
+			} {
+				got := fn.f(tt.nums, tt.k)
+				if got == nil || !equalInts(got, fn.want) {
+					t.Errorf("%s(%v, %d) = %#v, want %v", fn.name, tt.nums, tt.k, got, fn.want)
+				}
+			}
+		})
+	}
+	if got, err := MaxSlidingWindowFloat([]float64{1}, 2); err != nil || len(got) != 0 {
+		t.Fatalf("MaxSlidingWindowFloat with k > len = (%v, %v)", got, err)
+	}
+}
+
+func TestMaxSlidingWindowFloat(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		nums []float64
+		k    int
+		want []float64
+	}{
+		// Truncating to ints would tie 2.1 and 2.9 and 0.4 and 0.6.
+		{[]float64{2.1, 2.9, 0.4, 0.6, 0.5}, 2, []float64{2.9, 2.9, 0.6, 0.6}},
+		{[]float64{1.25, 1.5, 1.125, 1.375}, 3, []float64{1.5, 1.5}},
+		{[]float64{-inf, -1.5, inf, 3}, 2, []float64{-1.5, inf, inf}},
+		{[]float64{-inf, -inf}, 1, []float64{-inf, -inf}},
+	}
+	for _, tt := range tests {
+		got, err := MaxSlidingWindowFloat(tt.nums, tt.k)
+		if err != nil {
+			t.Fatalf("MaxSlidingWindowFloat(%v, %d): %v", tt.nums, tt.k, err)
+		}
+		if len(got) != len(tt.want) {
+			t.Fatalf("MaxSlidingWindowFloat(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Fatalf("MaxSlidingWindowFloat(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+			}
+		}
+	}
+	if _, err := MaxSlidingWindowFloat([]float64{1, math.NaN(), 2}, 2); err != ErrNaN {
+		t.Fatalf("NaN input returned err %v, want ErrNaN", err)
+	}
+}
+
+func TestSlidingWindowSum(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, r.Intn(60))
+		for i := range nums {
+			nums[i] = r.Intn(2001) - 1000
+		}
+		k := r.Intn(len(nums) + 3)
+		want := []int{}
+		for i := 0; k > 0 && i+k <= len(nums); i++ {
+			sum := 0
+			for _, x := range nums[i : i+k] {
+				sum += x
+			}
+			want = append(want, sum)
+		}
+		if got := SlidingWindowSum(nums, k); got == nil || !equalInts(got, want) {
+			t.Fatalf("SlidingWindowSum(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+	// The running sum overflows on the way, but every window fits.
+	big := []int{math.MaxInt, 5, -5, -math.MaxInt, 7}
+	if got, want := SlidingWindowSum(big, 3), []int{math.MaxInt, -math.MaxInt, 2 - math.MaxInt}; !equalInts(got, want) {
+		t.Fatalf("SlidingWindowSum(%v, 3) = %v, want %v", big, got, want)
+	}
+}
+
+func TestSlidingWindowDistinct(t *testing.T) {
+	tests := []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		{[]int{4, 4, 4, 4, 4}, 3, []int{1, 1, 1}},
+		{[]int{1, 2, 3, 4, 5}, 3, []int{3, 3, 3}},
+		// 1 leaves the window [1 2 3] just as another 1 arrives.
+		{[]int{1, 2, 3, 1, 2, 3}, 3, []int{3, 3, 3, 3}},
+		{[]int{1, 2, 1, 3, 3, 3}, 2, []int{2, 2, 2, 1, 1}},
+		{[]int{1, 2}, 0, []int{}},
+		{[]int{1, 2}, 3, []int{}},
+	}
+	for _, tt := range tests {
+		if got := SlidingWindowDistinct(tt.nums, tt.k); got == nil || !equalInts(got, tt.want) {
+			t.Errorf("SlidingWindowDistinct(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(47))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, 1+r.Intn(60))
+		for i := range nums {
+			nums[i] = r.Intn(8)
+		}
+		k := 1 + r.Intn(len(nums))
+		want := make([]int, 0, len(nums)-k+1)
+		for i := 0; i+k <= len(nums); i++ {
+			set := make(map[int]bool)
+			for _, x := range nums[i : i+k] {
+				set[x] = true
+			}
+			want = append(want, len(set))
+		}
+		if got := SlidingWindowDistinct(nums, k); !equalInts(got, want) {
+			t.Fatalf("SlidingWindowDistinct(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+}
+
+func TestSlidingWindowMode(t *testing.T) {
+	tests := []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		// The last 1 leaves with the third window, and so does the mode.
+		{[]int{1, 1, 2, 3, 3, 2}, 3, []int{1, 1, 3, 3}},
+		{[]int{4, 4, 9, 9}, 2, []int{4, 4, 9}},
+		// Every value occurs once, so the smallest wins each window.
+		{[]int{5, 3, 4, 1, 2}, 3, []int{3, 1, 1}},
+		{[]int{2, 2, 1, 1, 3, 3}, 4, []int{1, 1, 1}},
+		{[]int{7, 7, 7}, 1, []int{7, 7, 7}},
+		{[]int{1, 2}, 0, []int{}},
+		{[]int{1, 2}, 3, []int{}},
+	}
+	for _, tt := range tests {
+		if got := SlidingWindowMode(tt.nums, tt.k); got == nil || !equalInts(got, tt.want) {
+			t.Errorf("SlidingWindowMode(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(48))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, 1+r.Intn(60))
+		for i := range nums {
+			nums[i] = r.Intn(6) - 3
+		}
+		k := 1 + r.Intn(len(nums))
+		want := make([]int, 0, len(nums)-k+1)
+		for i := 0; i+k <= len(nums); i++ {
+			freq := make(map[int]int)
+			mode := nums[i]
+			for _, x := range nums[i : i+k] {
+				freq[x]++
+			}
+			for x, n := range freq {
+				if n > freq[mode] || n == freq[mode] && x < mode {
+					mode = x
+				}
+			}
+			want = append(want, mode)
+		}
+		if got := SlidingWindowMode(nums, k); !equalInts(got, want) {
+			t.Fatalf("SlidingWindowMode(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+}
+
+func TestSlidingWindowModeMemory(t *testing.T) {
+	// On a decreasing stream every expired value ranks below the live
+	// ones and never reaches the top; on an increasing one it does.
+	for _, step := range []int{-1, 1} {
+		const k = 10
+		nums := make([]int, 200000)
+		for i := range nums {
+			nums[i] = i * step
+		}
+		m := newWindowMode(k)
+		for i, x := range nums {
+			m.change(x, 1)
+			if i >= k {
+				m.change(nums[i-k], -1)
+			}
+			if i >= k-1 {
+				if got, want := m.mode(), min(nums[i], nums[i-k+1]); got != want {
+					t.Fatalf("step %d: mode of window ending at %d = %d, want %d", step, i, got, want)
+				}
+			}
+			if m.h.Len() > 2*k+2 {
+				t.Fatalf("step %d: after %d values the heap holds %d entries for a window of %d", step, i+1, m.h.Len(), k)
+			}
+		}
+	}
+	// Counts that rise and fall make old entries valid again; the sweep
+	// must still shrink the heap.
+	r := rand.New(rand.NewSource(48))
+	nums := make([]int, 50000)
+	for i := range nums {
+		nums[i] = r.Intn(3)
+	}
+	const k = 64
+	m := newWindowMode(k)
+	for i, x := range nums {
+		m.change(x, 1)
+		if i >= k {
+			m.change(nums[i-k], -1)
+		}
+		if m.h.Len() > 2*k+2 {
+			t.Fatalf("after %d values the heap holds %d entries for a window of %d", i+1, m.h.Len(), k)
+		}
+	}
+}
+
+func TestMovingAverage(t *testing.T) {
+	r := rand.New(rand.NewSource(39))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]float64, r.Intn(60))
+		for i := range nums {
+			nums[i] = r.Float64()*200 - 100
+		}
+		k := r.Intn(len(nums) + 3)
+		got := MovingAverage(nums, k)
+		if k <= 0 || k > len(nums) {
+			if got == nil || len(got) != 0 {
+				t.Fatalf("MovingAverage(%d values, %d) = %#v, want empty", len(nums), k, got)
+			}
+			continue
+		}
+		if len(got) != len(nums)-k+1 {
+			t.Fatalf("MovingAverage returned %d windows, want %d", len(got), len(nums)-k+1)
+		}
+		for i := range got {
+			sum := 0.0
+			for _, x := range nums[i : i+k] {
+				sum += x
+			}
+			if want := sum / float64(k); math.Abs(got[i]-want) > 1e-9 {
+				t.Fatalf("window %d of k=%d: MovingAverage = %v, want %v", i, k, got[i], want)
+			}
+		}
+	}
+
+	// A spike must not swallow the small readings after it has left.
+	if got := MovingAverage([]float64{1e16, 1, 1, 1, 1}, 2); fmt.Sprint(got) != "[5e+15 1 1 1]" {
+		t.Fatalf("MovingAverage after a spike = %v, want [5e15 1 1 1]", got)
+	}
+	spiky := make([]float64, 2000)
+	for i := range spiky {
+		spiky[i] = r.Float64()
+		if i%300 == 17 {
+			spiky[i] = 1e15 * (1 + r.Float64())
+		}
+	}
+	for _, k := range []int{1, 4, 50} {
+		got := MovingAverage(spiky, k)
+		for i := range got {
+			var sum, high float64
+			for _, x := range spiky[i : i+k] {
+				sum += x
+				high = max(high, x)
+			}
+			if high < 1 {
+				if want := sum / float64(k); math.Abs(got[i]-want) > 1e-12 {
+					t.Fatalf("k=%d: window %d after a spike averages %v, want %v", k, i, got[i], want)
+				}
+			}
+		}
+	}
+
+	inf := math.Inf(1)
+	got := MovingAverage([]float64{1, math.NaN(), 3, inf, 5, -inf, 7, 9}, 2)
+	for i, want := range []float64{math.NaN(), math.NaN(), inf, inf, -inf, -inf, 8} {
+		if math.IsNaN(want) != math.IsNaN(got[i]) || !math.IsNaN(want) && got[i] != want {
+			t.Fatalf("MovingAverage with non-finite readings = %v, window %d want %v", got, i, want)
+		}
+	}
+	if got := MovingAverage([]float64{inf, -inf}, 2); !math.IsNaN(got[0]) {
+		t.Fatalf("MovingAverage of +Inf and -Inf = %v, want NaN", got)
+	}
+}
+
+func TestSlidingWindowVariance(t *testing.T) {
+	got := SlidingWindowVariance([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 8)
+	if len(got) != 1 || math.Abs(got[0]-4) > 1e-12 {
+		t.Fatalf("population variance of the textbook example = %v, want [4]", got)
+	}
+	if got := SlidingWindowStdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}, 8); len(got) != 1 || math.Abs(got[0]-2) > 1e-12 {
+		t.Fatalf("SlidingWindowStdDev = %v, want [2]", got)
+	}
+	for _, v := range SlidingWindowVariance([]float64{3, -1, 1e9, 7}, 1) {
+		if v != 0 {
+			t.Fatalf("variance with k=1 = %v, want 0", v)
+		}
+	}
+	for _, k := range []int{0, -1, 5} {
+		if got := SlidingWindowVariance([]float64{1, 2, 3}, k); got == nil || len(got) != 0 {
+			t.Fatalf("SlidingWindowVariance with k=%d = %#v, want empty", k, got)
+		}
+	}
+
+	r := rand.New(rand.NewSource(50))
+	for _, offset := range []float64{0, 1e9} {
+		nums := make([]float64, 5000)
+		for i := range nums {
+			nums[i] = offset + r.Float64()
+		}
+		for _, k := range []int{2, 3, 10, 100} {
+			got := SlidingWindowVariance(nums, k)
+			std := SlidingWindowStdDev(nums, k)
+			for i := range got {
+				if want := twoPassVariance(nums[i : i+k]); math.Abs(got[i]-want) > 1e-9 {
+					t.Fatalf("offset %g k=%d: window %d variance = %v, want %v", offset, k, i, got[i], want)
+				}
+				if std[i] != math.Sqrt(got[i]) {
+					t.Fatalf("offset %g k=%d: window %d stddev = %v, want sqrt(%v)", offset, k, i, std[i], got[i])
+				}
+			}
+		}
+	}
+}
+
+func TestSlidingWindowVarianceLevelShift(t *testing.T) {
+	spike := []float64{1e9, 1e9 + 1, 1e9 + 2, 1, 2, 3, 1, 2, 3}
+	// A day of readings near 1e8 between two nights.
+	day := make([]float64, 0, 40)
+	for i := 0; i < 10; i++ {
+		day = append(day, 0)
+	}
+	for i := 0; i < 10; i++ {
+		day = append(day, 1e8+float64(i%3))
+	}
+	for i := 0; i < 20; i++ {
+		day = append(day, float64(i%3))
+	}
+	r := rand.New(rand.NewSource(50))
+	mixed := make([]float64, 3000)
+	for i := range mixed {
+		// Levels jump between 0, 1e4 and 1e9 every few hundred readings.
+		mixed[i] = []float64{0, 1e4, 1e9}[i/250%3] + r.Float64()
+	}
+	for _, nums := range [][]float64{spike, day, mixed} {
+		for _, k := range []int{1, 2, 3, 7, 40} {
+			got := SlidingWindowVariance(nums, k)
+			for i := range got {
+				want := twoPassVariance(nums[i : i+k])
+				if math.Abs(got[i]-want) > 1e-9*max(1, want) {
+					t.Fatalf("k=%d: window %d %v variance = %v, want %v", k, i, nums[i:min(i+k, i+5)], got[i], want)
+				}
+			}
+		}
+	}
+	if got := SlidingWindowVariance(spike, 3); math.Abs(got[len(got)-1]-2.0/3) > 1e-12 {
+		t.Fatalf("variance of [1 2 3] after a spike = %v, want 2/3", got[len(got)-1])
+	}
+}
+
+// twoPassVariance is the population variance computed from the mean,
+// after shifting by the first reading, which is exact for windows at one
+// level.
+func twoPassVariance(xs []float64) float64 {
+	var mean float64
+	for _, x := range xs {
+		mean += x - xs[0]
+	}
+	mean /= float64(len(xs))
+	var ss float64
+	for _, x := range xs {
+		d := x - xs[0] - mean
+		ss += d * d
+	}
+	return ss / float64(len(xs))
+}
+
+func TestWindowStats(t *testing.T) {
+	r := rand.New(rand.NewSource(42))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, 1+r.Intn(60))
+		for i := range nums {
+			nums[i] = r.Intn(21) - 10
+		}
+		k := 1 + r.Intn(len(nums))
+		got := WindowStats(nums, k)
+		mins, maxs := bruteMinWindow(nums, k), bruteMaxWindow(nums, k)
+		if len(got) != len(mins) {
+			t.Fatalf("WindowStats(%v, %d) has %d windows, want %d", nums, k, len(got), len(mins))
+		}
+		for i, s := range got {
+			sum := 0
+			for _, x := range nums[i : i+k] {
+				sum += x
+			}
+			want := WindowStat{Min: mins[i], Max: maxs[i], Mean: float64(sum) / float64(k)}
+			if s != want {
+				t.Fatalf("WindowStats(%v, %d)[%d] = %+v, want %+v", nums, k, i, s, want)
+			}
+		}
+	}
+	for _, k := range []int{0, -1, 4} {
+		if got := WindowStats([]int{1, 2, 3}, k); got == nil || len(got) != 0 {
+			t.Fatalf("WindowStats with k=%d = %#v, want empty", k, got)
+		}
+	}
+}
+
+func TestMaxSlidingWindowWithIndex(t *testing.T) {
+	nums := []int{3, 7, 7, 2, 7, 1, 1, 0}
+	maxes, idxs := MaxSlidingWindowWithIndex(nums, 3)
+	if want := []int{7, 7, 7, 7, 7, 1}; !equalInts(maxes, want) {
+		t.Fatalf("maxes = %v, want %v", maxes, want)
+	}
+	// Windows [1,3] and [2,4] each hold 7 twice; the earlier copy wins.
+	if want := []int{1, 1, 2, 4, 4, 5}; !equalInts(idxs, want) {
+		t.Fatalf("idxs = %v, want %v", idxs, want)
+	}
+
+	r := rand.New(rand.NewSource(45))
+	for iter := 0; iter < 300; iter++ {
+		nums := make([]int, 1+r.Intn(50))
+		for i := range nums {
+			nums[i] = r.Intn(5)
+		}
+		k := 1 + r.Intn(len(nums))
+		maxes, idxs := MaxSlidingWindowWithIndex(nums, k)
+		if want := bruteMaxWindow(nums, k); !equalInts(maxes, want) {
+			t.Fatalf("maxes of %v, k=%d = %v, want %v", nums, k, maxes, want)
+		}
+		for w, idx := range idxs {
+			first := w
+			for nums[first] != maxes[w] {
+				first++
+			}
+			if idx != first {
+				t.Fatalf("window %d of %v, k=%d: index %d, want the first maximum at %d", w, nums, k, idx, first)
+			}
+		}
+	}
+	if m, i := MaxSlidingWindowWithIndex(nil, 1); m == nil || i == nil || len(m)+len(i) != 0 {
+		t.Fatalf("MaxSlidingWindowWithIndex(nil, 1) = %#v, %#v", m, i)
+	}
+}
+
+func TestMaxSlidingWindowStride(t *testing.T) {
+	nums := []int{1, 3, -1, -3, 5, 3, 6, 7, 2}
+	tests := []struct {
+		k, stride int
+		want      []int
+	}{
+		{3, 1, []int{3, 3, 5, 5, 6, 7, 7}},
+		{3, 2, []int{3, 5, 6, 7}},
+		{3, 3, []int{3, 5, 7}},
+		{2, 4, []int{3, 5}}, // the window at 8 would run past the end
+		{4, 5, []int{3, 7}},
+		{9, 3, []int{7}},
+		{10, 1, []int{}},
+	}
+	for _, tt := range tests {
+		got, err := MaxSlidingWindowStride(nums, tt.k, tt.stride)
+		if err != nil || got == nil || !equalInts(got, tt.want) {
+			t.Errorf("MaxSlidingWindowStride(%v, %d, %d) = (%v, %v), want %v", nums, tt.k, tt.stride, got, err, tt.want)
+		}
+	}
+	for _, stride := range []int{0, -1} {
+		if _, err := MaxSlidingWindowStride(nums, 3, stride); !errors.Is(err, ErrStride) {
+			t.Errorf("stride %d returned err %v, want ErrStride", stride, err)
+		}
+	}
+
+	r := rand.New(rand.NewSource(46))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, 1+r.Intn(80))
+		for i := range nums {
+			nums[i] = r.Intn(20) - 10
+		}
+		k, stride := 1+r.Intn(len(nums)), 1+r.Intn(10)
+		all := maxSlidingWindow(nums, k)
+		var want []int
+		for i := 0; i < len(all); i += stride {
+			want = append(want, all[i])
+		}
+		got, _ := MaxSlidingWindowStride(nums, k, stride)
+		if !equalInts(got, want) {
+			t.Fatalf("MaxSlidingWindowStride(%v, %d, %d) = %v, want %v", nums, k, stride, got, want)
+		}
+		if stride == 1 && !equalInts(got, all) {
+			t.Fatalf("stride 1 differs from maxSlidingWindow")
+		}
+	}
+}
+
+func TestSlidingWindowRange(t *testing.T) {
+	tests := []struct {
+		nums []int
+		k    int
+		want []int
+	}{
+		{[]int{1, 3, -1, -3, 5, 3, 6, 7}, 3, []int{4, 6, 8, 8, 3, 4}},
+		{[]int{4, 4, 4, 4}, 2, []int{0, 0, 0}},
+		{[]int{-5, -9, -2}, 2, []int{4, 7}},
+		{[]int{1, 2}, 0, []int{}},
+		{[]int{1, 2}, 3, []int{}},
+	}
+	for _, tt := range tests {
+		if got := SlidingWindowRange(tt.nums, tt.k); got == nil || !equalInts(got, tt.want) {
+			t.Errorf("SlidingWindowRange(%v, %d) = %v, want %v", tt.nums, tt.k, got, tt.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(49))
+	for iter := 0; iter < 200; iter++ {
+		nums := make([]int, 1+r.Intn(60))
+		for i := range nums {
+			nums[i] = r.Intn(21) - 10
+		}
+		k := 1 + r.Intn(len(nums))
+		want := slidingWindowRangeTwoPass(nums, k)
+		if got := SlidingWindowRange(nums, k); !equalInts(got, want) {
+			t.Fatalf("SlidingWindowRange(%v, %d) = %v, want %v", nums, k, got, want)
+		}
+	}
+}
+
+// slidingWindowRangeTwoPass zips separate max and min passes.
+func slidingWindowRangeTwoPass(nums []int, k int) []int {
+	hi, lo := maxSlidingWindowDeque(nums, k), minSlidingWindowDeque(nums, k)
+	for i := range hi {
+		hi[i] -= lo[i]
+	}
+	return hi
+}
+
+func BenchmarkSlidingWindowRange(b *testing.B) {
+	r := rand.New(rand.NewSource(49))
+	nums := make([]int, 100000)
+	for i := range nums {
+		nums[i] = r.Intn(1 << 20)
+	}
+	for _, k := range []int{100, 500} {
+		b.Run(fmt.Sprint("k=", k, "/onepass"), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				SlidingWindowRange(nums, k)
+			}
+		})
+		b.Run(fmt.Sprint("k=", k, "/twopass"), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				slidingWindowRangeTwoPass(nums, k)
+			}
+		})
+	}
+}
+
+func FuzzMaxSlidingWindow(f *testing.F) {
+	f.Add([]byte{1, 3, 255, 253, 5, 3, 6, 7}, 3)
+	f.Add([]byte{4, 4, 4, 4}, 2)
+	f.Add([]byte{}, 1)
+	f.Add([]byte{9}, 0)
+	f.Fuzz(func(t *testing.T, data []byte, k int) {
+		nums := make([]int, len(data))
+		for i, b := range data {
+			nums[i] = int(int8(b))
+		}
+		heap, deque := maxSlidingWindow(nums, k), maxSlidingWindowDeque(nums, k)
+		want := []int{}
+		if k > 0 && k <= len(nums) {
+			want = bruteMaxWindow(nums, k)
+		}
+		if !equalInts(heap, want) || !equalInts(deque, want) {
+			t.Fatalf("maxSlidingWindow(%v, %d): heap %v, deque %v, want %v", nums, k, heap, deque, want)
+		}
+	})
+}
+
+func BenchmarkMaxSlidingWindow(b *testing.B) {
+	r := rand.New(rand.NewSource(26))
+	nums := make([]int, 100000)
+	for i := range nums {
+		nums[i] = r.Intn(1 << 20)
+	}
+	for _, k := range []int{100, 500} {
+		b.Run(fmt.Sprint("k=", k, "/heap"), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				maxSlidingWindow(nums, k)
+			}
+		})
+		b.Run(fmt.Sprint("k=", k, "/deque"), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				maxSlidingWindowDeque(nums, k)
+			}
+		})
+	}
+}